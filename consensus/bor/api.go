@@ -251,19 +251,26 @@ func (api *API) GetSignersAtHash(hash common.Hash) ([]common.Address, error) {
 	return snap.signers(), nil
 }
 
-// GetCurrentProposer gets the current proposer
-func (api *API) GetCurrentProposer() (common.Address, error) {
-	snap, err := api.GetSnapshot(nil)
+// GetCurrentProposer gets the current proposer, or the one at the given block
+// number to query historical spans
+func (api *API) GetCurrentProposer(number *rpc.BlockNumber) (common.Address, error) {
+	snap, err := api.GetSnapshot(number)
 	if err != nil {
 		return common.Address{}, err
 	}
 
-	return snap.ValidatorSet.GetProposer().Address, nil
+	proposer := snap.ValidatorSet.GetProposer()
+	if proposer == nil {
+		return common.Address{}, errUnknownValidators
+	}
+
+	return proposer.Address, nil
 }
 
-// GetCurrentValidators gets the current validators
-func (api *API) GetCurrentValidators() ([]*valset.Validator, error) {
-	snap, err := api.GetSnapshot(nil)
+// GetCurrentValidators gets the current validators, or the ones at the given
+// block number to query historical spans
+func (api *API) GetCurrentValidators(number *rpc.BlockNumber) ([]*valset.Validator, error) {
+	snap, err := api.GetSnapshot(number)
 	if err != nil {
 		return make([]*valset.Validator, 0), err
 	}
@@ -283,18 +290,19 @@ func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 		return root.(string), nil
 	}
 
-	length := end - start + 1
-
-	if length > MaxCheckpointLength {
-		return "", &MaxCheckpointLengthExceededError{start, end}
-	}
-
+	// Validate the range before its length, as an inverted one would underflow
 	currentHeaderNumber := api.chain.CurrentHeader().Number.Uint64()
 
 	if start > end || end > currentHeaderNumber {
 		return "", &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: currentHeaderNumber}
 	}
 
+	length := end - start + 1
+
+	if length > MaxCheckpointLength {
+		return "", &MaxCheckpointLengthExceededError{start, end}
+	}
+
 	blockHeaders := make([]*types.Header, end-start+1)
 	wg := new(sync.WaitGroup)
 	concurrent := make(chan bool, 20)
@@ -68,6 +68,14 @@ var (
 	// that is not part of the local blockchain.
 	errUnknownBlock = errors.New("unknown block")
 
+	// errMissingHeimdall is returned when data only available from heimdall is
+	// requested from a node running without it.
+	errMissingHeimdall = errors.New("heimdall client not configured")
+
+	// errUnknownSpan is returned when a span is requested that is not committed
+	// in the validator set contract.
+	errUnknownSpan = errors.New("unknown span")
+
 	// errMissingVanity is returned if a block's extra-data section is shorter than
 	// 32 bytes, which is required to store the signer vanity.
 	errMissingVanity = errors.New("extra-data 32 byte vanity prefix missing")
@@ -965,6 +973,16 @@ func (c *Bor) Authorize(currentSigner common.Address, signFn SignerFn) {
 	})
 }
 
+// AuthorizedSigner returns the address blocks are currently sealed with, or the
+// zero address if the engine wasn't authorized.
+func (c *Bor) AuthorizedSigner() common.Address {
+	if signer := c.authorizedSigner.Load(); signer != nil {
+		return signer.signer
+	}
+
+	return common.Address{}
+}
+
 // Seal implements consensus.Engine, attempting to create a sealed block using
 // the local signing credentials.
 func (c *Bor) Seal(ctx context.Context, chain consensus.ChainHeaderReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
@@ -1319,6 +1337,221 @@ func (c *Bor) GetCurrentValidators(ctx context.Context, headerHash common.Hash,
 	return c.spanner.GetCurrentValidatorsByHash(ctx, headerHash, blockNumber)
 }
 
+// SealingContext describes where a block being sealed sits within the span and
+// sprint schedule, from the point of view of the authorized signer.
+type SealingContext struct {
+	Signer           common.Address `json:"signer"`
+	Proposer         common.Address `json:"proposer"`
+	SpanID           uint64         `json:"spanId"`
+	SpanStart        uint64         `json:"spanStart"`
+	SpanEnd          uint64         `json:"spanEnd"`
+	Sprint           uint64         `json:"sprint"`
+	SprintPosition   uint64         `json:"sprintPosition"`
+	SuccessionNumber int            `json:"successionNumber"`
+	InTurn           bool           `json:"inTurn"`
+}
+
+// GetSealingContext returns the sealing context of the given pending header for
+// the currently authorized signer.
+func (c *Bor) GetSealingContext(ctx context.Context, chain consensus.ChainHeaderReader, header *types.Header) (*SealingContext, error) {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return nil, errUnknownBlock
+	}
+
+	currentSigner := c.authorizedSigner.Load().signer
+
+	snap, err := c.snapshot(chain, number-1, header.ParentHash, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if !snap.ValidatorSet.HasAddress(currentSigner) {
+		// Check the UnauthorizedSignerError.Error() msg to see why we pass number-1
+		return nil, &UnauthorizedSignerError{number - 1, currentSigner.Bytes()}
+	}
+
+	successionNumber, err := snap.GetSignerSuccessionNumber(currentSigner)
+	if err != nil {
+		return nil, err
+	}
+
+	currentSpan, err := c.spanner.GetCurrentSpan(ctx, header.ParentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	sprint := c.config.CalculateSprint(number)
+
+	sealingContext := &SealingContext{
+		Signer:           currentSigner,
+		SpanID:           currentSpan.ID,
+		SpanStart:        currentSpan.StartBlock,
+		SpanEnd:          currentSpan.EndBlock,
+		Sprint:           sprint,
+		SuccessionNumber: successionNumber,
+		InTurn:           successionNumber == 0,
+	}
+
+	if sprint > 0 {
+		sealingContext.SprintPosition = number % sprint
+	}
+
+	if proposer := snap.ValidatorSet.GetProposer(); proposer != nil {
+		sealingContext.Proposer = proposer.Address
+	}
+
+	return sealingContext, nil
+}
+
+// ExportedSnapshot is the serializable form of a validator snapshot, extended
+// with the proposer and sprint schedule at the snapshot block.
+type ExportedSnapshot struct {
+	Number         uint64                    `json:"number"`
+	Hash           common.Hash               `json:"hash"`
+	ValidatorSet   *valset.ValidatorSet      `json:"validatorSet"`
+	Proposer       common.Address            `json:"proposer"`
+	Recents        map[uint64]common.Address `json:"recents"`
+	Sprint         uint64                    `json:"sprint"`
+	SprintStart    uint64                    `json:"sprintStart"`
+	SprintPosition uint64                    `json:"sprintPosition"`
+}
+
+// SpanInfo describes the span covering a block and when the next one begins.
+type SpanInfo struct {
+	ID            uint64              `json:"id"`
+	StartBlock    uint64              `json:"startBlock"`
+	EndBlock      uint64              `json:"endBlock"`
+	NextSpanStart uint64              `json:"nextSpanStart"`
+	Producers     []*valset.Validator `json:"producers,omitempty"`
+}
+
+// SpanInfo returns the span committed in the validator set contract at the given
+// header, along with its selected producers if the snapshot is available.
+func (c *Bor) SpanInfo(ctx context.Context, chain consensus.ChainHeaderReader, header *types.Header) (*SpanInfo, error) {
+	s, err := c.spanner.GetCurrentSpan(ctx, header.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	info := &SpanInfo{
+		ID:            s.ID,
+		StartBlock:    s.StartBlock,
+		EndBlock:      s.EndBlock,
+		NextSpanStart: s.EndBlock + 1,
+	}
+
+	snap, err := c.snapshot(chain, header.Number.Uint64(), header.Hash(), nil)
+	if err != nil {
+		log.Debug("Span producers unavailable", "number", header.Number, "hash", header.Hash(), "err", err)
+		return info, nil
+	}
+
+	info.Producers = snap.copy().ValidatorSet.Validators
+
+	return info, nil
+}
+
+// SpanStartBlock resolves the span with the given id to its first block, as
+// committed in the validator set contract at the given header.
+func (c *Bor) SpanStartBlock(ctx context.Context, id uint64, header *types.Header) (uint64, error) {
+	s, err := c.spanner.GetSpan(ctx, id, header.Hash())
+	if err != nil {
+		return 0, err
+	}
+
+	// The contract returns a zeroed span for ids it doesn't know about
+	if s.ID != id {
+		return 0, fmt.Errorf("%w: %d", errUnknownSpan, id)
+	}
+
+	return s.StartBlock, nil
+}
+
+// StateSyncEvents returns the state-sync events committed by the given block.
+// They're only committed at the start of sprints, so the result is empty for
+// all the other blocks. The applied id range is read from the state receiver
+// contract around the block, and the records are fetched from heimdall.
+func (c *Bor) StateSyncEvents(ctx context.Context, header *types.Header) ([]*types.StateSyncData, error) {
+	number := header.Number.Uint64()
+	if number == 0 || !IsSprintStart(number, c.config.CalculateSprint(number)) {
+		return []*types.StateSyncData{}, nil
+	}
+
+	if c.HeimdallClient == nil {
+		return nil, errMissingHeimdall
+	}
+
+	before, err := c.GenesisContractsClient.LastStateId(nil, number-1, header.ParentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := c.GenesisContractsClient.LastStateId(nil, number, header.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if after.Cmp(before) <= 0 {
+		return []*types.StateSyncData{}, nil
+	}
+
+	stateSyncs := make([]*types.StateSyncData, 0, after.Uint64()-before.Uint64())
+
+	// Committed events can't be more recent than the block itself
+	eventRecords, err := c.HeimdallClient.StateSyncEvents(ctx, before.Uint64()+1, int64(header.Time))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, eventRecord := range eventRecords {
+		if eventRecord.ID <= before.Uint64() || eventRecord.ID > after.Uint64() {
+			continue
+		}
+
+		stateSyncs = append(stateSyncs, &types.StateSyncData{
+			ID:       eventRecord.ID,
+			Contract: eventRecord.Contract,
+			Data:     hex.EncodeToString(eventRecord.Data),
+			TxHash:   eventRecord.TxHash,
+		})
+	}
+
+	return stateSyncs, nil
+}
+
+// ExportSnapshot returns the validator snapshot at the given header in a form
+// that round-trips through JSON.
+func (c *Bor) ExportSnapshot(chain consensus.ChainHeaderReader, header *types.Header) (*ExportedSnapshot, error) {
+	number := header.Number.Uint64()
+
+	snap, err := c.snapshot(chain, number, header.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	snap = snap.copy()
+
+	exported := &ExportedSnapshot{
+		Number:       snap.Number,
+		Hash:         snap.Hash,
+		ValidatorSet: snap.ValidatorSet,
+		Recents:      snap.Recents,
+		Sprint:       c.config.CalculateSprint(number),
+	}
+
+	if exported.Sprint > 0 {
+		exported.SprintPosition = number % exported.Sprint
+		exported.SprintStart = number - exported.SprintPosition
+	}
+
+	if proposer := snap.ValidatorSet.GetProposer(); proposer != nil {
+		exported.Proposer = proposer.Address
+	}
+
+	return exported, nil
+}
+
 //
 // Private methods
 //
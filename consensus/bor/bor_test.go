@@ -1,19 +1,28 @@
 package bor
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil" //nolint:typecheck
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/tests/bor/mocks"
 )
 
 func TestGenesisContractChange(t *testing.T) {
@@ -142,3 +151,259 @@ func TestEncodeSigHeaderJaipur(t *testing.T) {
 	hash = SealHash(h, &params.BorConfig{JaipurBlock: big.NewInt(10)})
 	require.Equal(t, hash, hashWithoutBaseFee)
 }
+
+func TestSpanStartBlock(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	header := &types.Header{Number: big.NewInt(1000)}
+
+	spanner := NewMockSpanner(ctrl)
+	spanner.EXPECT().GetSpan(gomock.Any(), uint64(1), header.Hash()).Return(&span.Span{ID: 1, StartBlock: 256, EndBlock: 6655}, nil)
+	spanner.EXPECT().GetSpan(gomock.Any(), uint64(5), header.Hash()).Return(&span.Span{}, nil)
+
+	b := &Bor{spanner: spanner}
+
+	start, err := b.SpanStartBlock(context.Background(), 1, header)
+	require.NoError(t, err)
+	require.Equal(t, uint64(256), start)
+
+	_, err = b.SpanStartBlock(context.Background(), 5, header)
+	require.ErrorIs(t, err, errUnknownSpan)
+}
+
+func TestStateSyncEvents(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parent := &types.Header{Number: big.NewInt(15)}
+	header := &types.Header{Number: big.NewInt(16), ParentHash: parent.Hash(), Time: 1000}
+
+	contracts := NewMockGenesisContract(ctrl)
+	contracts.EXPECT().LastStateId(nil, uint64(15), parent.Hash()).Return(big.NewInt(4), nil)
+	contracts.EXPECT().LastStateId(nil, uint64(16), header.Hash()).Return(big.NewInt(6), nil)
+
+	record := func(id uint64) *clerk.EventRecordWithTime {
+		return &clerk.EventRecordWithTime{EventRecord: clerk.EventRecord{ID: id, Contract: common.Address{0x1}, Data: []byte{byte(id)}}}
+	}
+
+	heimdall := mocks.NewMockIHeimdallClient(ctrl)
+	heimdall.EXPECT().StateSyncEvents(gomock.Any(), uint64(5), int64(1000)).Return([]*clerk.EventRecordWithTime{record(5), record(6), record(7)}, nil)
+
+	b := &Bor{
+		config:                 &params.BorConfig{Sprint: map[string]uint64{"0": 16}},
+		GenesisContractsClient: contracts,
+		HeimdallClient:         heimdall,
+	}
+
+	// Only the events between the state ids around the block were applied
+	stateSyncs, err := b.StateSyncEvents(context.Background(), header)
+	require.NoError(t, err)
+	require.Len(t, stateSyncs, 2)
+	require.Equal(t, uint64(5), stateSyncs[0].ID)
+	require.Equal(t, "05", stateSyncs[0].Data)
+	require.Equal(t, uint64(6), stateSyncs[1].ID)
+
+	// No events are applied outside of sprint starts
+	stateSyncs, err = b.StateSyncEvents(context.Background(), parent)
+	require.NoError(t, err)
+	require.Empty(t, stateSyncs)
+}
+
+func TestSpanInfo(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	header := &types.Header{Number: big.NewInt(1000)}
+	producer := valset.NewValidator(common.Address{0x1}, 10)
+
+	spanner := NewMockSpanner(ctrl)
+	spanner.EXPECT().GetCurrentSpan(gomock.Any(), header.Hash()).Return(&span.Span{ID: 1, StartBlock: 256, EndBlock: 6655}, nil)
+
+	recents, _ := lru.NewARC(inmemorySnapshots)
+	recents.Add(header.Hash(), &Snapshot{Number: 1000, Hash: header.Hash(), ValidatorSet: valset.NewValidatorSet([]*valset.Validator{producer})})
+
+	b := &Bor{spanner: spanner, recents: recents}
+	b.authorizedSigner.Store(&signer{})
+
+	info, err := b.SpanInfo(context.Background(), nil, header)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), info.ID)
+	require.Equal(t, uint64(256), info.StartBlock)
+	require.Equal(t, uint64(6655), info.EndBlock)
+	require.Equal(t, uint64(6656), info.NextSpanStart)
+	require.Len(t, info.Producers, 1)
+	require.Equal(t, producer.Address, info.Producers[0].Address)
+}
+
+func TestGetSealingContext(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parent := &types.Header{Number: big.NewInt(999)}
+	header := &types.Header{Number: big.NewInt(1000), ParentHash: parent.Hash()}
+
+	validators := valset.NewValidatorSet([]*valset.Validator{
+		valset.NewValidator(common.Address{0x1}, 10),
+		valset.NewValidator(common.Address{0x2}, 10),
+	})
+	proposer := validators.GetProposer().Address
+
+	backup := common.Address{0x1}
+	if proposer == backup {
+		backup = common.Address{0x2}
+	}
+
+	spanner := NewMockSpanner(ctrl)
+	spanner.EXPECT().GetCurrentSpan(gomock.Any(), parent.Hash()).Return(&span.Span{ID: 1, StartBlock: 256, EndBlock: 6655}, nil).Times(2)
+
+	recents, _ := lru.NewARC(inmemorySnapshots)
+	recents.Add(parent.Hash(), &Snapshot{Number: 999, Hash: parent.Hash(), ValidatorSet: validators})
+
+	b := &Bor{
+		config:  &params.BorConfig{Sprint: map[string]uint64{"0": 16}},
+		spanner: spanner,
+		recents: recents,
+	}
+
+	// The in-turn proposer may seal right away
+	b.authorizedSigner.Store(&signer{signer: proposer})
+
+	sealing, err := b.GetSealingContext(context.Background(), nil, header)
+	require.NoError(t, err)
+	require.True(t, sealing.InTurn)
+	require.Equal(t, 0, sealing.SuccessionNumber)
+	require.Equal(t, proposer, sealing.Signer)
+	require.Equal(t, proposer, sealing.Proposer)
+	require.Equal(t, uint64(1), sealing.SpanID)
+	require.Equal(t, uint64(16), sealing.Sprint)
+	require.Equal(t, uint64(1000%16), sealing.SprintPosition)
+
+	// A backup producer is reported along with its succession number
+	b.authorizedSigner.Store(&signer{signer: backup})
+
+	sealing, err = b.GetSealingContext(context.Background(), nil, header)
+	require.NoError(t, err)
+	require.False(t, sealing.InTurn)
+	require.Equal(t, 1, sealing.SuccessionNumber)
+	require.Equal(t, proposer, sealing.Proposer)
+
+	// Signers outside the validator set are rejected
+	b.authorizedSigner.Store(&signer{signer: common.Address{0x3}})
+
+	_, err = b.GetSealingContext(context.Background(), nil, header)
+
+	var unauthorized *UnauthorizedSignerError
+	require.ErrorAs(t, err, &unauthorized)
+
+	// The genesis block has no sealing context
+	_, err = b.GetSealingContext(context.Background(), nil, &types.Header{Number: big.NewInt(0)})
+	require.ErrorIs(t, err, errUnknownBlock)
+}
+
+// headChain is a header reader only serving the current header.
+type headChain struct {
+	consensus.ChainHeaderReader
+	head *types.Header
+}
+
+func (c *headChain) CurrentHeader() *types.Header { return c.head }
+
+func TestGetRootHashRange(t *testing.T) {
+	t.Parallel()
+
+	api := &API{chain: &headChain{head: &types.Header{Number: new(big.Int).SetUint64(2 * MaxCheckpointLength)}}}
+
+	// An inverted range must be reported as such rather than as too long
+	var rangeErr *valset.InvalidStartEndBlockError
+
+	_, err := api.GetRootHash(10, 5)
+	require.ErrorAs(t, err, &rangeErr)
+
+	_, err = api.GetRootHash(1, 2*MaxCheckpointLength+1)
+	require.ErrorAs(t, err, &rangeErr)
+
+	var lengthErr *MaxCheckpointLengthExceededError
+
+	_, err = api.GetRootHash(1, MaxCheckpointLength+1)
+	require.ErrorAs(t, err, &lengthErr)
+}
+
+// numberChain is a header reader serving a fixed set of headers by number, the
+// last one being the current header.
+type numberChain struct {
+	consensus.ChainHeaderReader
+	headers []*types.Header
+}
+
+func (c *numberChain) CurrentHeader() *types.Header { return c.headers[len(c.headers)-1] }
+
+func (c *numberChain) GetHeaderByNumber(number uint64) *types.Header {
+	for _, header := range c.headers {
+		if header.Number.Uint64() == number {
+			return header
+		}
+	}
+
+	return nil
+}
+
+func TestGetCurrentValidatorsAtBlock(t *testing.T) {
+	t.Parallel()
+
+	var (
+		old     = &types.Header{Number: big.NewInt(100)}
+		current = &types.Header{Number: big.NewInt(7000)}
+
+		oldValidator     = valset.NewValidator(common.Address{0x1}, 10)
+		currentValidator = valset.NewValidator(common.Address{0x2}, 10)
+	)
+
+	recents, _ := lru.NewARC(inmemorySnapshots)
+	recents.Add(old.Hash(), &Snapshot{Number: 100, Hash: old.Hash(), ValidatorSet: valset.NewValidatorSet([]*valset.Validator{oldValidator})})
+	recents.Add(current.Hash(), &Snapshot{Number: 7000, Hash: current.Hash(), ValidatorSet: valset.NewValidatorSet([]*valset.Validator{currentValidator})})
+
+	b := &Bor{recents: recents}
+	b.authorizedSigner.Store(&signer{})
+
+	api := &API{chain: &numberChain{headers: []*types.Header{old, current}}, bor: b}
+
+	// Without a block number, the current validators are returned
+	validators, err := api.GetCurrentValidators(nil)
+	require.NoError(t, err)
+	require.Len(t, validators, 1)
+	require.Equal(t, currentValidator.Address, validators[0].Address)
+
+	proposer, err := api.GetCurrentProposer(nil)
+	require.NoError(t, err)
+	require.Equal(t, currentValidator.Address, proposer)
+
+	// Historical spans are served at the requested block
+	number := rpc.BlockNumber(100)
+
+	validators, err = api.GetCurrentValidators(&number)
+	require.NoError(t, err)
+	require.Len(t, validators, 1)
+	require.Equal(t, oldValidator.Address, validators[0].Address)
+
+	proposer, err = api.GetCurrentProposer(&number)
+	require.NoError(t, err)
+	require.Equal(t, oldValidator.Address, proposer)
+
+	// Unknown blocks are rejected
+	number = rpc.BlockNumber(5000)
+
+	_, err = api.GetCurrentValidators(&number)
+	require.ErrorIs(t, err, errUnknownBlock)
+
+	_, err = api.GetCurrentProposer(&number)
+	require.ErrorIs(t, err, errUnknownBlock)
+}
@@ -45,6 +45,7 @@ type HeimdallClient struct {
 	urlString string
 	client    http.Client
 	closeCh   chan struct{}
+	noRetry   bool // Whether requests are tried once, leaving the retries to the caller
 }
 
 type Request struct {
@@ -85,7 +86,7 @@ func (h *HeimdallClient) StateSyncEvents(ctx context.Context, fromID uint64, to
 
 		ctx = withRequestType(ctx, stateSyncRequest)
 
-		response, err := FetchWithRetry[StateSyncEventsResponse](ctx, h.client, url, h.closeCh)
+		response, err := fetch[StateSyncEventsResponse](ctx, h, url)
 		if err != nil {
 			return nil, err
 		}
@@ -119,7 +120,7 @@ func (h *HeimdallClient) Span(ctx context.Context, spanID uint64) (*span.Heimdal
 
 	ctx = withRequestType(ctx, spanRequest)
 
-	response, err := FetchWithRetry[SpanResponse](ctx, h.client, url, h.closeCh)
+	response, err := fetch[SpanResponse](ctx, h, url)
 	if err != nil {
 		return nil, err
 	}
@@ -136,7 +137,7 @@ func (h *HeimdallClient) FetchCheckpoint(ctx context.Context, number int64) (*ch
 
 	ctx = withRequestType(ctx, checkpointRequest)
 
-	response, err := FetchWithRetry[checkpoint.CheckpointResponse](ctx, h.client, url, h.closeCh)
+	response, err := fetch[checkpoint.CheckpointResponse](ctx, h, url)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +154,7 @@ func (h *HeimdallClient) FetchCheckpointCount(ctx context.Context) (int64, error
 
 	ctx = withRequestType(ctx, checkpointCountRequest)
 
-	response, err := FetchWithRetry[checkpoint.CheckpointCountResponse](ctx, h.client, url, h.closeCh)
+	response, err := fetch[checkpoint.CheckpointCountResponse](ctx, h, url)
 	if err != nil {
 		return 0, err
 	}
@@ -161,6 +162,16 @@ func (h *HeimdallClient) FetchCheckpointCount(ctx context.Context) (int64, error
 	return response.Result.Result, nil
 }
 
+// fetch returns data from heimdall, retrying until it succeeds unless the client
+// leaves the retries to its caller.
+func fetch[T any](ctx context.Context, h *HeimdallClient, url *url.URL) (*T, error) {
+	if h.noRetry {
+		return Fetch[T](ctx, &Request{client: h.client, url: url, start: time.Now()})
+	}
+
+	return FetchWithRetry[T](ctx, h.client, url, h.closeCh)
+}
+
 // FetchWithRetry returns data from heimdall with retry
 func FetchWithRetry[T any](ctx context.Context, client http.Client, url *url.URL, closeCh chan struct{}) (*T, error) {
 	// request data once
@@ -0,0 +1,171 @@
+package heimdall
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// FailoverHeimdallClient wraps a set of heimdall clients and transparently
+// switches to the next endpoint whenever the active one can't be reached.
+type FailoverHeimdallClient struct {
+	clients []*HeimdallClient
+	current atomic.Int32 // Index of the endpoint which served the last request
+	closeCh chan struct{}
+}
+
+// NewFailoverHeimdallClient creates a heimdall client failing over between the
+// given endpoints, in the order they are provided.
+func NewFailoverHeimdallClient(urlStrings ...string) *FailoverHeimdallClient {
+	clients := make([]*HeimdallClient, 0, len(urlStrings))
+	for _, urlString := range urlStrings {
+		client := NewHeimdallClient(urlString)
+		client.noRetry = true
+
+		clients = append(clients, client)
+	}
+
+	return &FailoverHeimdallClient{clients: clients, closeCh: make(chan struct{})}
+}
+
+// Endpoint returns the URL of the endpoint currently used to serve requests.
+func (f *FailoverHeimdallClient) Endpoint() string {
+	return f.clients[f.current.Load()].urlString
+}
+
+// call runs fn against the endpoints until it succeeds, retrying every retryCall
+// like a single client would. Each round starts with the active endpoint and
+// only moves on to the next ones if it can't be reached.
+func (f *FailoverHeimdallClient) call(ctx context.Context, fn func(ctx context.Context, client *HeimdallClient) error) error {
+	err := f.callOnce(ctx, fn)
+	if err == nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(retryCall)
+	defer ticker.Stop()
+
+	for attempt := 1; ; attempt++ {
+		log.Warn("an error while trying fetching from Heimdall", "attempt", attempt, "endpoint", f.Endpoint(), "error", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-f.closeCh:
+			return ErrShutdownDetected
+		case <-ticker.C:
+			if err = f.callOnce(ctx, fn); err == nil {
+				return nil
+			}
+		}
+	}
+}
+
+// callOnce runs fn once against the active endpoint and, if it can't be reached,
+// against the next endpoints in round-robin order until one of them answers.
+// Errors reported by a reachable endpoint are returned without failing over.
+func (f *FailoverHeimdallClient) callOnce(ctx context.Context, fn func(ctx context.Context, client *HeimdallClient) error) error {
+	var (
+		start = int(f.current.Load())
+		err   error
+	)
+
+	for i := 0; i < len(f.clients); i++ {
+		idx := (start + i) % len(f.clients)
+		client := f.clients[idx]
+
+		if err = fn(ctx, client); err == nil {
+			if idx != start {
+				log.Info("Switched heimdall endpoint", "endpoint", client.urlString)
+			}
+
+			f.current.Store(int32(idx))
+
+			return nil
+		}
+
+		// Don't bother with the remaining endpoints if the caller gave up, or
+		// if the endpoint answered, as another one isn't expected to do better
+		if ctx.Err() != nil || !isNetworkError(err) {
+			return err
+		}
+
+		log.Warn("Heimdall endpoint unreachable, trying next", "endpoint", client.urlString, "err", err)
+	}
+
+	return err
+}
+
+// isNetworkError reports whether the given error means the endpoint couldn't be
+// reached, as opposed to an error response or an invalid payload.
+func isNetworkError(err error) bool {
+	var netErr net.Error
+
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func (f *FailoverHeimdallClient) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	var events []*clerk.EventRecordWithTime
+
+	err := f.call(ctx, func(ctx context.Context, client *HeimdallClient) (err error) {
+		events, err = client.StateSyncEvents(ctx, fromID, to)
+		return err
+	})
+
+	return events, err
+}
+
+func (f *FailoverHeimdallClient) Span(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	var heimdallSpan *span.HeimdallSpan
+
+	err := f.call(ctx, func(ctx context.Context, client *HeimdallClient) (err error) {
+		heimdallSpan, err = client.Span(ctx, spanID)
+		return err
+	})
+
+	return heimdallSpan, err
+}
+
+// FetchCheckpoint fetches the checkpoint from the first heimdall endpoint able to serve it
+func (f *FailoverHeimdallClient) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	var cp *checkpoint.Checkpoint
+
+	err := f.call(ctx, func(ctx context.Context, client *HeimdallClient) (err error) {
+		if cp, err = client.FetchCheckpoint(ctx, number); err == nil {
+			log.Debug("Fetched checkpoint from heimdall", "number", number, "endpoint", client.urlString)
+		}
+
+		return err
+	})
+
+	return cp, err
+}
+
+// FetchCheckpointCount fetches the checkpoint count from the first heimdall endpoint able to serve it
+func (f *FailoverHeimdallClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := f.call(ctx, func(ctx context.Context, client *HeimdallClient) (err error) {
+		count, err = client.FetchCheckpointCount(ctx)
+		return err
+	})
+
+	return count, err
+}
+
+// Close closes all the underlying clients
+func (f *FailoverHeimdallClient) Close() {
+	close(f.closeCh)
+
+	for _, client := range f.clients {
+		client.Close()
+	}
+}
@@ -0,0 +1,135 @@
+package heimdall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/network"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFetchCheckpointWithFailover tests that the failover client moves on to the
+// next heimdall endpoint when the active one is unreachable.
+func TestFetchCheckpointWithFailover(t *testing.T) {
+	t.Parallel()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	handler := &HttpHandlerFake{}
+	handler.handleFetchCheckpoint = func(w http.ResponseWriter, _ *http.Request) {
+		err := json.NewEncoder(w).Encode(checkpoint.CheckpointResponse{
+			Height: "0",
+			Result: checkpoint.Checkpoint{
+				Proposer:   common.Address{},
+				StartBlock: big.NewInt(0),
+				EndBlock:   big.NewInt(512),
+				RootHash:   common.Hash{},
+				BorChainID: "15001",
+				Timestamp:  0,
+			},
+		})
+
+		if err != nil {
+			w.WriteHeader(500) // Return 500 Internal Server Error.
+		}
+	}
+
+	// Reserve a port nobody listens on for the unreachable endpoint
+	deadPort, deadListener, err := network.FindAvailablePort()
+	require.NoError(t, err, "expect no error in finding available port")
+	require.NoError(t, deadListener.Close())
+
+	port, listener, err := network.FindAvailablePort()
+	require.NoError(t, err, "expect no error in finding available port")
+
+	srv, err := CreateMockHeimdallServer(wg, port, listener, handler)
+	require.NoError(t, err, "expect no error in starting mock heimdall server")
+
+	liveURL := fmt.Sprintf("http://localhost:%d", port)
+	client := NewFailoverHeimdallClient(fmt.Sprintf("http://localhost:%d", deadPort), liveURL)
+
+	cp, err := client.FetchCheckpoint(context.Background(), -1)
+	require.NoError(t, err, "expect no error in fetching checkpoint")
+	require.Equal(t, uint64(512), cp.EndBlock.Uint64())
+	require.Equal(t, liveURL, client.Endpoint(), "expect live endpoint to become active")
+
+	err = srv.Shutdown(context.TODO())
+	require.NoError(t, err, "expect no error in shutting down mock heimdall server")
+
+	wg.Wait()
+}
+
+// TestFetchCheckpointWithoutFailoverOnErrorResponse tests that the failover client
+// sticks to the active heimdall endpoint if it answers with an error, as opposed
+// to being unreachable.
+func TestFetchCheckpointWithoutFailoverOnErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+
+	var primaryHits, secondaryHits atomic.Int32
+
+	primary := &HttpHandlerFake{}
+	primary.handleFetchCheckpoint = func(w http.ResponseWriter, _ *http.Request) {
+		primaryHits.Add(1)
+		w.WriteHeader(500) // Return 500 Internal Server Error.
+	}
+
+	secondary := &HttpHandlerFake{}
+	secondary.handleFetchCheckpoint = func(w http.ResponseWriter, _ *http.Request) {
+		secondaryHits.Add(1)
+		_ = json.NewEncoder(w).Encode(checkpoint.CheckpointResponse{Height: "0"})
+	}
+
+	primaryPort, primaryListener, err := network.FindAvailablePort()
+	require.NoError(t, err, "expect no error in finding available port")
+
+	primarySrv, err := CreateMockHeimdallServer(wg, primaryPort, primaryListener, primary)
+	require.NoError(t, err, "expect no error in starting mock heimdall server")
+
+	secondaryPort, secondaryListener, err := network.FindAvailablePort()
+	require.NoError(t, err, "expect no error in finding available port")
+
+	secondarySrv, err := CreateMockHeimdallServer(wg, secondaryPort, secondaryListener, secondary)
+	require.NoError(t, err, "expect no error in starting mock heimdall server")
+
+	// Make sure the primary endpoint is up, so it can't be mistaken as unreachable
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", fmt.Sprintf("localhost:%d", primaryPort))
+		if err == nil {
+			conn.Close()
+		}
+
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond, "expect the primary endpoint to come up")
+
+	primaryURL := fmt.Sprintf("http://localhost:%d", primaryPort)
+	client := NewFailoverHeimdallClient(primaryURL, fmt.Sprintf("http://localhost:%d", secondaryPort))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err = client.FetchCheckpoint(ctx, -1)
+	require.Error(t, err, "expect an error in fetching checkpoint")
+	require.NotZero(t, primaryHits.Load(), "expect the primary endpoint to be queried")
+	require.Zero(t, secondaryHits.Load(), "expect no failover to the secondary endpoint")
+	require.Equal(t, primaryURL, client.Endpoint(), "expect primary endpoint to stay active")
+
+	require.NoError(t, primarySrv.Shutdown(context.TODO()), "expect no error in shutting down mock heimdall server")
+	require.NoError(t, secondarySrv.Shutdown(context.TODO()), "expect no error in shutting down mock heimdall server")
+
+	wg.Wait()
+}
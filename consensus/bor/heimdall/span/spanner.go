@@ -40,15 +40,23 @@ func NewChainSpanner(ethAPI api.Caller, validatorSet abi.ABI, chainConfig *param
 
 // GetCurrentSpan get current span from contract
 func (c *ChainSpanner) GetCurrentSpan(ctx context.Context, headerHash common.Hash) (*Span, error) {
+	return c.callSpan(ctx, headerHash, "getCurrentSpan")
+}
+
+// GetSpan gets the span with the given id from the contract, as of the given
+// block. Spans not committed yet at that block are returned zeroed.
+func (c *ChainSpanner) GetSpan(ctx context.Context, id uint64, headerHash common.Hash) (*Span, error) {
+	return c.callSpan(ctx, headerHash, "getSpan", new(big.Int).SetUint64(id))
+}
+
+// callSpan calls a validator set contract method returning a span.
+func (c *ChainSpanner) callSpan(ctx context.Context, headerHash common.Hash, method string, args ...interface{}) (*Span, error) {
 	// block
 	blockNr := rpc.BlockNumberOrHashWithHash(headerHash, false)
 
-	// method
-	const method = "getCurrentSpan"
-
-	data, err := c.validatorSet.Pack(method)
+	data, err := c.validatorSet.Pack(method, args...)
 	if err != nil {
-		log.Error("Unable to pack tx for getCurrentSpan", "error", err)
+		log.Error("Unable to pack tx for "+method, "error", err)
 
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package bor
 
 import (
+	"encoding/json"
 	"math/big"
 	"sort"
 	"testing"
@@ -18,6 +19,37 @@ const (
 	numVals = 100
 )
 
+func TestExportedSnapshotJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	validatorSet := valset.NewValidatorSet(buildRandomValidatorSet(numVals))
+	exported := &ExportedSnapshot{
+		Number:       42,
+		Hash:         common.HexToHash("0x01"),
+		ValidatorSet: validatorSet,
+		Proposer:     validatorSet.GetProposer().Address,
+		Recents: map[uint64]common.Address{
+			40: validatorSet.Validators[0].Address,
+			41: validatorSet.Validators[1].Address,
+		},
+		Sprint:         16,
+		SprintStart:    32,
+		SprintPosition: 10,
+	}
+
+	blob, err := json.Marshal(exported)
+	require.NoError(t, err)
+
+	var decoded ExportedSnapshot
+	require.NoError(t, json.Unmarshal(blob, &decoded))
+
+	reencoded, err := json.Marshal(&decoded)
+	require.NoError(t, err)
+	require.JSONEq(t, string(blob), string(reencoded))
+	require.Equal(t, exported.Recents, decoded.Recents)
+	require.Equal(t, exported.Proposer, decoded.Proposer)
+}
+
 func TestGetSignerSuccessionNumber_ProposerIsSigner(t *testing.T) {
 	t.Parallel()
 
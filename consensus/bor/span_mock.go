@@ -99,3 +99,18 @@ func (mr *MockSpannerMockRecorder) GetCurrentValidatorsByHash(arg0, arg1, arg2 i
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidatorsByHash", reflect.TypeOf((*MockSpanner)(nil).GetCurrentValidatorsByHash), arg0, arg1, arg2)
 }
+
+// GetSpan mocks base method.
+func (m *MockSpanner) GetSpan(arg0 context.Context, arg1 uint64, arg2 common.Hash) (*span.Span, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpan", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*span.Span)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpan indicates an expected call of GetSpan.
+func (mr *MockSpannerMockRecorder) GetSpan(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpan", reflect.TypeOf((*MockSpanner)(nil).GetSpan), arg0, arg1, arg2)
+}
@@ -92,6 +92,17 @@ var (
 	blockExecutionParallelCounter = metrics.NewRegisteredCounter("chain/execution/parallel", nil)
 	blockExecutionSerialCounter   = metrics.NewRegisteredCounter("chain/execution/serial", nil)
 
+	blockExecutionParallelFallbackCounter = metrics.NewRegisteredCounter("chain/execution/parallel/fallback", nil)
+
+	blockExecutionShadowVerifiedCounter = metrics.NewRegisteredCounter("chain/execution/shadow/verified", nil)
+	blockExecutionShadowMismatchCounter = metrics.NewRegisteredCounter("chain/execution/shadow/mismatch", nil)
+
+	importHookTimeoutCounter = metrics.NewRegisteredCounter("chain/importhook/timeout", nil)
+	importHookSkipCounter    = metrics.NewRegisteredCounter("chain/importhook/skip", nil)
+
+	serialExecutionMetrics   = newExecutionMetrics("serial")
+	parallelExecutionMetrics = newExecutionMetrics("parallel")
+
 	blockReorgMeter     = metrics.NewRegisteredMeter("chain/reorg/executes", nil)
 	blockReorgAddMeter  = metrics.NewRegisteredMeter("chain/reorg/add", nil)
 	blockReorgDropMeter = metrics.NewRegisteredMeter("chain/reorg/drop", nil)
@@ -101,6 +112,9 @@ var (
 
 	errInsertionInterrupted = errors.New("insertion is interrupted")
 	errChainStopped         = errors.New("blockchain is stopped")
+	errSnapshotDisabled     = errors.New("state snapshot is disabled")
+	errSnapshotGenerating   = errors.New("state snapshot generation already in progress")
+	errTrieJournalDisabled  = errors.New("trie clean cache journal is disabled")
 )
 
 const (
@@ -153,6 +167,12 @@ type CacheConfig struct {
 
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
+
+	MaxReorgDepth uint64 // Maximum number of canonical blocks a reorg may drop (0 = unlimited)
+
+	ExecutionMetrics bool // Whether to record per block execution time, transaction count and gas used histograms
+
+	ReadOnly bool // Whether the database is opened read-only, skipping the genesis setup and tx indexing
 }
 
 // DefaultCacheConfig are the default caching values if none are specified by the
@@ -232,6 +252,7 @@ type BlockChain struct {
 	quit          chan struct{}  // shutdown signal, closed in Stop.
 	stopping      atomic.Bool    // false if chain is running, true when stopped
 	procInterrupt atomic.Bool    // interrupt signaler for block processing
+	insertPaused  atomic.Bool    // whether block import is paused until resumed
 
 	engine            consensus.Engine
 	validator         Validator // Block and state validator interface
@@ -246,6 +267,14 @@ type BlockChain struct {
 	stateSyncData    []*types.StateSyncData                  // State sync data
 	stateSyncFeed    event.Feed                              // State sync feed
 	chain2HeadFeed   event.Feed                              // Reorg/NewHead/Fork data feed
+
+	shadowMismatchHook atomic.Pointer[ShadowMismatchHook] // Invoked on parallel and serial execution mismatches in shadow mode
+	importHooks        atomic.Pointer[[]importHookPair]   // Pre and post block import hooks, replaced on registration
+	importHooksLock    sync.Mutex                         // Serializes the import hook registrations
+	importHookTasks    chan func()                        // Hook invocations handed to the hook workers
+	importHookSlots    chan struct{}                      // Hook workers busy with an invocation
+
+	importFailureReported bool // Whether the running insertion reported its failure to the post-import hooks (protected by chainmu)
 }
 
 // NewBlockChain returns a fully initialised block chain using information
@@ -270,7 +299,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	// Setup the genesis block, commit the provided genesis specification
 	// to database if the genesis block is not present yet, or load the
 	// stored one from database.
-	chainConfig, genesisHash, genesisErr := SetupGenesisBlockWithOverride(db, triedb, genesis, overrides)
+	chainConfig, genesisHash, genesisErr := setupGenesis(db, triedb, genesis, overrides, cacheConfig.ReadOnly)
 	if _, ok := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !ok {
 		return nil, genesisErr
 	}
@@ -480,8 +509,8 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 
 		rawdb.WriteChainConfig(db, genesisHash, chainConfig)
 	}
-	// Start tx indexer/unindexer if required.
-	if txLookupLimit != nil {
+	// Start tx indexer/unindexer if required, which can't update a read-only database.
+	if txLookupLimit != nil && !cacheConfig.ReadOnly {
 		bc.txLookupLimit = *txLookupLimit
 
 		bc.wg.Add(1)
@@ -492,8 +521,22 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	return bc, nil
 }
 
+// setupGenesis sets up the genesis block and chain config in the database, or
+// only loads the stored ones if it's opened read-only.
+func setupGenesis(db ethdb.Database, triedb *trie.Database, genesis *Genesis, overrides *ChainOverrides, readOnly bool) (*params.ChainConfig, common.Hash, error) {
+	if readOnly {
+		return LoadChainConfig(db, genesis, overrides)
+	}
+
+	return SetupGenesisBlockWithOverride(db, triedb, genesis, overrides)
+}
+
 // NewParallelBlockChain , similar to NewBlockChain, creates a new blockchain object, but with a parallel state processor
 func NewParallelBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis, overrides *ChainOverrides, engine consensus.Engine, vmConfig vm.Config, shouldPreserve func(header *types.Header) bool, txLookupLimit *uint64, checker ethereum.ChainValidator) (*BlockChain, error) {
+	if maxWorkers := vmConfig.ParallelMaxWorkers; maxWorkers < 0 || (maxWorkers > 0 && maxWorkers < blockstm.MinWorkers) {
+		return nil, fmt.Errorf("%w: %d, want 0 (unlimited) or at least %d", ErrInvalidParallelWorkers, maxWorkers, blockstm.MinWorkers)
+	}
+
 	bc, err := NewBlockChain(db, cacheConfig, genesis, overrides, engine, vmConfig, shouldPreserve, txLookupLimit, checker)
 
 	if err != nil {
@@ -506,7 +549,7 @@ func NewParallelBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis
 		Journal:   cacheConfig.TrieCleanJournal,
 		Preimages: cacheConfig.Preimages,
 	})
-	chainConfig, _, genesisErr := SetupGenesisBlockWithOverride(db, triedb, genesis, overrides)
+	chainConfig, _, genesisErr := setupGenesis(db, triedb, genesis, overrides, cacheConfig.ReadOnly)
 
 	if _, ok := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !ok {
 		return nil, genesisErr
@@ -514,31 +557,32 @@ func NewParallelBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis
 
 	bc.parallelProcessor = NewParallelStateProcessor(chainConfig, bc, engine)
 
+	log.Info("Parallel block processing enabled", "speculative", blockstm.LimitSpeculativeProcs(vmConfig.ParallelSpeculativeProcesses, vmConfig.ParallelMaxWorkers),
+		"configured", vmConfig.ParallelSpeculativeProcesses, "maxworkers", vmConfig.ParallelMaxWorkers)
+
 	return bc, nil
 }
 
 func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (types.Receipts, []*types.Log, uint64, *state.StateDB, error) {
+	receipts, logs, usedGas, statedb, _, err := bc.processBlock(block, parent)
+	return receipts, logs, usedGas, statedb, err
+}
+
+// processBlock is ProcessBlock, additionally reporting whether the result was
+// produced by the parallel processor.
+func (bc *BlockChain) processBlock(block *types.Block, parent *types.Header) (types.Receipts, []*types.Log, uint64, *state.StateDB, bool, error) {
 	// Process the block using processor and parallelProcessor at the same time, take the one which finishes first, cancel the other, and return the result
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	type Result struct {
-		receipts types.Receipts
-		logs     []*types.Log
-		usedGas  uint64
-		err      error
-		statedb  *state.StateDB
-		counter  metrics.Counter
-	}
-
-	resultChan := make(chan Result, 2)
+	resultChan := make(chan processResult, 2)
 
 	processorCount := 0
 
 	if bc.parallelProcessor != nil {
 		parallelStatedb, err := state.New(parent.Root, bc.stateCache, bc.snaps)
 		if err != nil {
-			return nil, nil, 0, nil, err
+			return nil, nil, 0, nil, false, err
 		}
 
 		processorCount++
@@ -546,14 +590,14 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 		go func() {
 			parallelStatedb.StartPrefetcher("chain")
 			receipts, logs, usedGas, err := bc.parallelProcessor.Process(block, parallelStatedb, bc.vmConfig, ctx)
-			resultChan <- Result{receipts, logs, usedGas, err, parallelStatedb, blockExecutionParallelCounter}
+			resultChan <- processResult{receipts, logs, usedGas, err, parallelStatedb, blockExecutionParallelCounter, true}
 		}()
 	}
 
 	if bc.processor != nil {
 		statedb, err := state.New(parent.Root, bc.stateCache, bc.snaps)
 		if err != nil {
-			return nil, nil, 0, nil, err
+			return nil, nil, 0, nil, false, err
 		}
 
 		processorCount++
@@ -561,7 +605,7 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 		go func() {
 			statedb.StartPrefetcher("chain")
 			receipts, logs, usedGas, err := bc.processor.Process(block, statedb, bc.vmConfig, ctx)
-			resultChan <- Result{receipts, logs, usedGas, err, statedb, blockExecutionSerialCounter}
+			resultChan <- processResult{receipts, logs, usedGas, err, statedb, blockExecutionSerialCounter, false}
 		}()
 	}
 
@@ -578,6 +622,13 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 		}
 	}
 
+	// In shadow mode, wait for both processors and compare their results, the
+	// parallel one staying canonical
+	if processorCount == 2 && bc.vmConfig.ParallelShadowVerify {
+		result = bc.shadowVerify(block, result, <-resultChan)
+		processorCount--
+	}
+
 	result.counter.Inc(1)
 
 	// Make sure we are not leaking any prefetchers
@@ -588,7 +639,67 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 		}()
 	}
 
-	return result.receipts, result.logs, result.usedGas, result.statedb, result.err
+	return result.receipts, result.logs, result.usedGas, result.statedb, result.parallel, result.err
+}
+
+// processResult is the outcome of executing a block with one of the processors.
+type processResult struct {
+	receipts types.Receipts
+	logs     []*types.Log
+	usedGas  uint64
+	err      error
+	statedb  *state.StateDB
+	counter  metrics.Counter
+	parallel bool
+}
+
+// processBlockSerially re-executes a block with the serial processor only, used
+// to recover from the parallel processor producing an invalid state.
+func (bc *BlockChain) processBlockSerially(block *types.Block, parent *types.Header) (types.Receipts, []*types.Log, uint64, *state.StateDB, error) {
+	statedb, err := state.New(parent.Root, bc.stateCache, bc.snaps)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+
+	statedb.StartPrefetcher("chain")
+
+	receipts, logs, usedGas, err := bc.processor.Process(block, statedb, bc.vmConfig, context.Background())
+	blockExecutionSerialCounter.Inc(1)
+
+	return receipts, logs, usedGas, statedb, err
+}
+
+// executionMetrics holds the per block execution histograms of a processor.
+type executionMetrics struct {
+	time metrics.Timer     // Time spent processing the block
+	txs  metrics.Histogram // Number of transactions in the block
+	gas  metrics.Histogram // Gas used by the block
+}
+
+// newExecutionMetrics registers the execution histograms of the named processor.
+func newExecutionMetrics(processor string) *executionMetrics {
+	return &executionMetrics{
+		time: metrics.NewRegisteredTimer("chain/execution/"+processor+"/time", nil),
+		txs:  metrics.NewRegisteredHistogram("chain/execution/"+processor+"/txs", nil, metrics.NewExpDecaySample(1028, 0.015)),
+		gas:  metrics.NewRegisteredHistogram("chain/execution/"+processor+"/gas", nil, metrics.NewExpDecaySample(1028, 0.015)),
+	}
+}
+
+// update records the execution of the given block.
+func (m *executionMetrics) update(block *types.Block, elapsed time.Duration) {
+	m.time.Update(elapsed)
+	m.txs.Update(int64(len(block.Transactions())))
+	m.gas.Update(int64(block.GasUsed()))
+}
+
+// executionMetrics returns the execution histograms of the processor the chain
+// is configured with.
+func (bc *BlockChain) executionMetrics() *executionMetrics {
+	if bc.vmConfig.ParallelEnable {
+		return parallelExecutionMetrics
+	}
+
+	return serialExecutionMetrics
 }
 
 // empty returns an indicator whether the blockchain is empty.
@@ -997,6 +1108,76 @@ func (bc *BlockChain) SnapSyncCommitHead(hash common.Hash) error {
 	return nil
 }
 
+// RegenerateSnapshot wipes the state snapshot and regenerates it in the
+// background from the state of the current head block. Only one generation
+// may run at a time.
+func (bc *BlockChain) RegenerateSnapshot() error {
+	if bc.snaps == nil {
+		return errSnapshotDisabled
+	}
+
+	// Hold the chain mutex to not race with block imports updating the snapshot
+	if !bc.chainmu.TryLock() {
+		return errChainStopped
+	}
+	defer bc.chainmu.Unlock()
+
+	if _, marker, err := bc.snaps.Generation(); err == nil && marker != nil {
+		return errSnapshotGenerating
+	}
+
+	head := bc.CurrentBlock()
+	bc.snaps.Rebuild(head.Root)
+
+	log.Info("Regenerating state snapshot", "number", head.Number, "root", head.Root)
+
+	return nil
+}
+
+// FlushTrieCacheJournal writes the trie clean cache to its journal right away
+// rather than waiting for the next periodic rejournal, e.g. ahead of a planned
+// shutdown. The path of the journal is returned.
+func (bc *BlockChain) FlushTrieCacheJournal() (string, error) {
+	journal := bc.cacheConfig.TrieCleanJournal
+	if journal == "" {
+		return "", errTrieJournalDisabled
+	}
+
+	return journal, bc.triedb.SaveCache(journal)
+}
+
+// RecomputeStateRoot re-executes the given block on top of its parent state with
+// the processors the chain is configured with and returns the resulting state
+// root, along with whether it was produced by the parallel processor. Nothing is
+// written to the database. Imports are held off meanwhile, as processing a bor
+// block records its state-sync data on the chain.
+func (bc *BlockChain) RecomputeStateRoot(block *types.Block) (common.Hash, bool, error) {
+	parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return common.Hash{}, false, consensus.ErrUnknownAncestor
+	}
+
+	if !bc.HasState(parent.Root) {
+		return common.Hash{}, false, fmt.Errorf("%w: state of block #%d unavailable", consensus.ErrPrunedAncestor, parent.Number)
+	}
+
+	if !bc.chainmu.TryLock() {
+		return common.Hash{}, false, errChainStopped
+	}
+	defer bc.chainmu.Unlock()
+
+	_, _, _, statedb, parallel, err := bc.processBlock(block, parent)
+	if statedb != nil {
+		statedb.StopPrefetcher()
+	}
+
+	if err != nil {
+		return common.Hash{}, parallel, err
+	}
+
+	return statedb.IntermediateRoot(bc.chainConfig.IsEIP158(block.Number())), parallel, nil
+}
+
 // Reset purges the entire blockchain, restoring it to its genesis state.
 func (bc *BlockChain) Reset() error {
 	return bc.ResetWithGenesisBlock(bc.genesisBlock)
@@ -1210,6 +1391,22 @@ func (bc *BlockChain) StopInsert() {
 	bc.procInterrupt.Store(true)
 }
 
+// PauseInsert makes InsertChain reject blocks with ErrInsertPaused until
+// ResumeInsert is called. Imports already running are left to complete.
+func (bc *BlockChain) PauseInsert() {
+	bc.insertPaused.Store(true)
+}
+
+// ResumeInsert re-enables block import paused by PauseInsert.
+func (bc *BlockChain) ResumeInsert() {
+	bc.insertPaused.Store(false)
+}
+
+// InsertPaused reports whether block import is paused.
+func (bc *BlockChain) InsertPaused() bool {
+	return bc.insertPaused.Load()
+}
+
 // insertStopped returns true after StopInsert has been called.
 func (bc *BlockChain) insertStopped() bool {
 	return bc.procInterrupt.Load()
@@ -1879,6 +2076,10 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 		return 0, nil
 	}
 
+	if bc.InsertPaused() {
+		return 0, ErrInsertPaused
+	}
+
 	bc.blockProcFeed.Send(true)
 
 	defer bc.blockProcFeed.Send(false)
@@ -1916,7 +2117,18 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 // racey behaviour. If a sidechain import is in progress, and the historic state
 // is imported, but then new canon-head is added before the actual sidechain
 // completes, then the historic state could be pruned again
-func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool) (int, error) {
+func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool) (n int, err error) {
+	// Report the first block not imported to the post-import hooks on any failed
+	// exit, unless a nested insertion (side chain, recovered ancestors) already
+	// reported its own failure.
+	bc.importFailureReported = false
+	defer func() {
+		if err != nil && n < len(chain) && !bc.importFailureReported {
+			bc.runPostImportHooks(chain[n], err)
+		}
+		bc.importFailureReported = err != nil
+	}()
+
 	// If the chain is terminating, don't even bother starting up.
 	if bc.insertStopped() {
 		return 0, nil
@@ -2154,6 +2366,8 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool)
 			continue
 		}
 
+		bc.runPreImportHooks(block)
+
 		// Retrieve the parent block and it's state to execute on top
 		start := time.Now()
 
@@ -2193,7 +2407,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool)
 
 		// Process block using the parent state as reference point
 		pstart := time.Now()
-		receipts, logs, usedGas, statedb, err := bc.ProcessBlock(block, parent)
+		receipts, logs, usedGas, statedb, parallel, err := bc.processBlock(block, parent)
 		activeState = statedb
 
 		if err != nil {
@@ -2213,10 +2427,33 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool)
 		vstart := time.Now()
 
 		if err := bc.validator.ValidateState(block, statedb, receipts, usedGas); err != nil {
-			bc.reportBlock(block, receipts, err)
-			followupInterrupt.Store(true)
+			if !parallel || bc.vmConfig.ParallelFatalOnDivergence {
+				bc.reportBlock(block, receipts, err)
+				followupInterrupt.Store(true)
 
-			return it.index, err
+				return it.index, err
+			}
+
+			// The parallel processor diverged, fall back to re-executing the
+			// block serially rather than halting the import
+			log.Error("Parallel execution diverged, re-executing block serially", "number", block.Number(), "hash", block.Hash(), "err", err)
+			blockExecutionParallelFallbackCounter.Inc(1)
+
+			statedb.StopPrefetcher()
+
+			receipts, logs, usedGas, statedb, err = bc.processBlockSerially(block, parent)
+			activeState = statedb
+
+			if err == nil {
+				err = bc.validator.ValidateState(block, statedb, receipts, usedGas)
+			}
+
+			if err != nil {
+				bc.reportBlock(block, receipts, err)
+				followupInterrupt.Store(true)
+
+				return it.index, err
+			}
 		}
 
 		vtime := time.Since(vstart)
@@ -2238,6 +2475,10 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool)
 		blockExecutionTimer.Update(ptime - trieRead)                    // The time spent on EVM processing
 		blockValidationTimer.Update(vtime - (triehash + trieUpdate))    // The time spent on block validation
 
+		if bc.cacheConfig.ExecutionMetrics {
+			bc.executionMetrics().update(block, ptime)
+		}
+
 		// Write the block to the chain and get the status.
 		var (
 			wstart = time.Now()
@@ -2256,6 +2497,9 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool)
 		if err != nil {
 			return it.index, err
 		}
+
+		bc.runPostImportHooks(block, nil)
+
 		// Update the metrics touched during block commit
 		accountCommitTimer.Update(statedb.AccountCommits)   // Account commits are complete, we can mark them
 		storageCommitTimer.Update(statedb.StorageCommits)   // Storage commits are complete, we can mark them
@@ -2649,6 +2893,15 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Block) error {
 		}
 	}
 
+	// Refuse reorgs deeper than allowed, they require manual intervention
+	if limit := bc.cacheConfig.MaxReorgDepth; limit > 0 && uint64(len(oldChain)) > limit && len(newChain) > 0 {
+		log.Error("Refusing chain reorg deeper than the limit, manual intervention required",
+			"number", commonBlock.Number(), "hash", commonBlock.Hash(), "drop", len(oldChain), "limit", limit,
+			"dropfrom", oldChain[0].Hash(), "add", len(newChain), "addfrom", newChain[0].Hash())
+
+		return fmt.Errorf("%w: dropping %d blocks, limit %d", ErrReorgTooDeep, len(oldChain), limit)
+	}
+
 	// Ensure the user sees large reorgs
 	if len(oldChain) > 0 && len(newChain) > 0 {
 		bc.chain2HeadFeed.Send(Chain2HeadEvent{
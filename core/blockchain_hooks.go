@@ -0,0 +1,157 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// importHookTimeout is the maximum time block import waits for a single hook.
+	// Hooks still running past it are left to finish in the background, so a slow
+	// hook can delay, but never stall, the import of a block.
+	importHookTimeout = 100 * time.Millisecond
+
+	// importHookWorkers is the number of goroutines running the import hooks. A
+	// hook running past its timeout keeps its worker busy, and once all of them
+	// are, further hooks are skipped instead of piling up goroutines.
+	importHookWorkers = 4
+)
+
+// PreImportHook is invoked with every block about to be executed and imported.
+type PreImportHook func(block *types.Block)
+
+// PostImportHook is invoked with every block after its import is attempted,
+// along with the import result. Blocks rejected before execution (e.g. due to
+// an invalid header) are only reported to the post-import hooks.
+type PostImportHook func(block *types.Block, err error)
+
+// importHookPair is a set of hooks registered together, either may be nil.
+type importHookPair struct {
+	pre  PreImportHook
+	post PostImportHook
+}
+
+// AddImportHooks registers a pair of hooks invoked synchronously from the block
+// import path, before and after each block is imported. Either hook may be nil.
+//
+// Hooks run on the consensus critical path, each one being waited for at most
+// importHookTimeout before the import proceeds without it. They must be fast
+// and must not call back into the blockchain's import methods.
+func (bc *BlockChain) AddImportHooks(pre PreImportHook, post PostImportHook) {
+	if pre == nil && post == nil {
+		return
+	}
+
+	bc.importHooksLock.Lock()
+	defer bc.importHooksLock.Unlock()
+
+	var hooks []importHookPair
+	if current := bc.importHooks.Load(); current != nil {
+		hooks = append(hooks, *current...)
+	} else {
+		bc.importHookTasks = make(chan func(), importHookWorkers)
+		bc.importHookSlots = make(chan struct{}, importHookWorkers)
+
+		for i := 0; i < importHookWorkers; i++ {
+			go bc.importHookWorker()
+		}
+	}
+
+	hooks = append(hooks, importHookPair{pre: pre, post: post})
+	bc.importHooks.Store(&hooks)
+}
+
+// runPreImportHooks invokes the registered pre-import hooks with the block.
+func (bc *BlockChain) runPreImportHooks(block *types.Block) {
+	hooks := bc.importHooks.Load()
+	if hooks == nil {
+		return
+	}
+
+	for _, hook := range *hooks {
+		if hook.pre != nil {
+			pre := hook.pre
+			bc.runImportHook("pre", block, func() { pre(block) })
+		}
+	}
+}
+
+// runPostImportHooks invokes the registered post-import hooks with the block
+// and its import result.
+func (bc *BlockChain) runPostImportHooks(block *types.Block, err error) {
+	hooks := bc.importHooks.Load()
+	if hooks == nil {
+		return
+	}
+
+	for _, hook := range *hooks {
+		if hook.post != nil {
+			post := hook.post
+			bc.runImportHook("post", block, func() { post(block, err) })
+		}
+	}
+}
+
+// importHookWorker runs the hook invocations handed over by runImportHook until
+// the chain is stopped.
+func (bc *BlockChain) importHookWorker() {
+	for {
+		select {
+		case task := <-bc.importHookTasks:
+			task()
+		case <-bc.quit:
+			return
+		}
+	}
+}
+
+// runImportHook runs a single hook on a hook worker, waiting at most
+// importHookTimeout for it to return. The hook is skipped if all workers are
+// still busy with earlier hooks that timed out.
+func (bc *BlockChain) runImportHook(kind string, block *types.Block, fn func()) {
+	select {
+	case bc.importHookSlots <- struct{}{}:
+	default:
+		log.Warn("Block import hook skipped, all hook workers busy", "kind", kind, "number", block.Number(), "hash", block.Hash(), "workers", importHookWorkers)
+		importHookSkipCounter.Inc(1)
+
+		return
+	}
+
+	done := make(chan struct{})
+
+	bc.importHookTasks <- func() {
+		fn()
+
+		<-bc.importHookSlots
+		close(done)
+	}
+
+	timer := time.NewTimer(importHookTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+		log.Warn("Block import hook timed out", "kind", kind, "number", block.Number(), "hash", block.Hash(), "timeout", importHookTimeout)
+		importHookTimeoutCounter.Inc(1)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that the import hooks are invoked in order around every imported block,
+// and that the post-import hooks receive the import failures.
+func TestImportHooks(t *testing.T) {
+	t.Parallel()
+
+	_, gspec, blockchain, err := newCanonical(ethash.NewFakeFailer(3), 0, true)
+	if err != nil {
+		t.Fatalf("failed to create pristine chain: %v", err)
+	}
+	defer blockchain.Stop()
+
+	var (
+		lock    sync.Mutex
+		calls   []string
+		failure error
+	)
+
+	blockchain.AddImportHooks(
+		func(block *types.Block) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			calls = append(calls, "pre "+block.Number().String())
+		},
+		func(block *types.Block, err error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			calls = append(calls, "post "+block.Number().String())
+			if err != nil {
+				failure = err
+			}
+		},
+	)
+	blockchain.AddImportHooks(nil, nil) // Ignored
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 4, nil)
+	if _, err := blockchain.InsertChain(blocks); err == nil {
+		t.Fatalf("import of the failing block succeeded")
+	}
+
+	want := []string{"pre 1", "post 1", "pre 2", "post 2", "post 3"}
+	if len(calls) != len(want) {
+		t.Fatalf("hook calls mismatch: have %v, want %v", calls, want)
+	}
+
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("hook call %d mismatch: have %s, want %s", i, calls[i], want[i])
+		}
+	}
+
+	if failure == nil {
+		t.Fatalf("post-import hook missed the import failure")
+	}
+}
+
+// Tests that a hook failing to return in time does not stall the block import.
+func TestImportHookTimeout(t *testing.T) {
+	t.Parallel()
+
+	_, gspec, blockchain, err := newCanonical(ethash.NewFaker(), 0, true)
+	if err != nil {
+		t.Fatalf("failed to create pristine chain: %v", err)
+	}
+	defer blockchain.Stop()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	blockchain.AddImportHooks(func(block *types.Block) { <-release }, nil)
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, nil)
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to import blocks: %v", err)
+	}
+
+	if head := blockchain.CurrentBlock().Number.Uint64(); head != 2 {
+		t.Fatalf("head mismatch: have %d, want %d", head, 2)
+	}
+}
+
+// Tests that import failures are reported to the post-import hooks through the
+// import paths other than InsertChain too.
+func TestImportHooksWithoutSetHead(t *testing.T) {
+	t.Parallel()
+
+	_, gspec, blockchain, err := newCanonical(ethash.NewFakeFailer(1), 0, true)
+	if err != nil {
+		t.Fatalf("failed to create pristine chain: %v", err)
+	}
+	defer blockchain.Stop()
+
+	failures := make(chan error, 1)
+	blockchain.AddImportHooks(nil, func(block *types.Block, err error) { failures <- err })
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 1, nil)
+
+	err = blockchain.InsertBlockWithoutSetHead(blocks[0])
+	if err == nil {
+		t.Fatalf("import of the failing block succeeded")
+	}
+
+	select {
+	case failure := <-failures:
+		if !errors.Is(failure, err) {
+			t.Fatalf("reported failure mismatch: have %v, want %v", failure, err)
+		}
+	default:
+		t.Fatalf("post-import hook missed the import failure")
+	}
+}
+
+// Tests that hooks stuck past their timeout only occupy the bounded hook
+// workers, after which further hooks are skipped instead of spawning more.
+func TestImportHookWorkers(t *testing.T) {
+	t.Parallel()
+
+	_, gspec, blockchain, err := newCanonical(ethash.NewFaker(), 0, true)
+	if err != nil {
+		t.Fatalf("failed to create pristine chain: %v", err)
+	}
+	defer blockchain.Stop()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	var calls atomic.Int32
+	blockchain.AddImportHooks(func(block *types.Block) {
+		calls.Add(1)
+		<-release
+	}, nil)
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), importHookWorkers+2, nil)
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to import blocks: %v", err)
+	}
+
+	if head := blockchain.CurrentBlock().Number.Uint64(); head != uint64(len(blocks)) {
+		t.Fatalf("head mismatch: have %d, want %d", head, len(blocks))
+	}
+
+	if have := calls.Load(); have != importHookWorkers {
+		t.Fatalf("stuck hook invocations mismatch: have %d, want %d", have, importHookWorkers)
+	}
+}
@@ -351,6 +351,24 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
 	return state.New(root, bc.stateCache, bc.snaps)
 }
 
+// PrefetchBlock executes the transactions of the given block on top of its parent
+// state, discarding the results, to warm up the caches with the state it touches.
+func (bc *BlockChain) PrefetchBlock(block *types.Block) error {
+	parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return consensus.ErrUnknownAncestor
+	}
+
+	statedb, err := bc.StateAt(parent.Root)
+	if err != nil {
+		return err
+	}
+
+	bc.prefetcher.Prefetch(block, statedb, bc.vmConfig, nil)
+
+	return nil
+}
+
 // Config retrieves the chain's fork configuration.
 func (bc *BlockChain) Config() *params.ChainConfig { return bc.chainConfig }
 
@@ -0,0 +1,106 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// ShadowMismatchHook is invoked with the block and the discrepancy found when
+// the parallel and serial execution of the block disagree in shadow mode.
+type ShadowMismatchHook func(block *types.Block, err error)
+
+// SetShadowMismatchHook installs a hook notified about the parallel and serial
+// execution mismatches found in shadow mode, replacing any previous one.
+func (bc *BlockChain) SetShadowMismatchHook(hook ShadowMismatchHook) {
+	bc.shadowMismatchHook.Store(&hook)
+}
+
+// shadowVerify compares the results of the two processors executing the block,
+// logging and counting any discrepancy. The parallel result is returned as the
+// canonical one, unless the parallel processor failed to execute the block at
+// all. The prefetcher of the other result is stopped. Consensus is unaffected,
+// as the returned result is validated against the block as usual.
+func (bc *BlockChain) shadowVerify(block *types.Block, first, second processResult) processResult {
+	parallel, serial := first, second
+	if !first.parallel {
+		parallel, serial = second, first
+	}
+
+	if err := compareExecutions(bc.chainConfig, block, parallel, serial); err != nil {
+		log.Error("Parallel execution mismatch in shadow mode", "number", block.Number(), "hash", block.Hash(), "err", err)
+		blockExecutionShadowMismatchCounter.Inc(1)
+
+		if hook := bc.shadowMismatchHook.Load(); hook != nil && *hook != nil {
+			(*hook)(block, err)
+		}
+	} else {
+		blockExecutionShadowVerifiedCounter.Inc(1)
+	}
+
+	canonical, other := parallel, serial
+	if parallel.err != nil && serial.err == nil {
+		canonical, other = serial, parallel
+	}
+
+	other.statedb.StopPrefetcher()
+
+	return canonical
+}
+
+// compareExecutions returns an error describing the first discrepancy between
+// the parallel and serial execution of the block, if any.
+func compareExecutions(config *params.ChainConfig, block *types.Block, parallel, serial processResult) error {
+	if (parallel.err == nil) != (serial.err == nil) {
+		return fmt.Errorf("%w: parallel error %v, serial error %v", ErrShadowMismatch, parallel.err, serial.err)
+	}
+
+	if parallel.err != nil {
+		return nil // Both processors rejected the block
+	}
+
+	if parallel.usedGas != serial.usedGas {
+		return fmt.Errorf("%w: gas used parallel %d, serial %d", ErrShadowMismatch, parallel.usedGas, serial.usedGas)
+	}
+
+	if len(parallel.logs) != len(serial.logs) {
+		return fmt.Errorf("%w: logs parallel %d, serial %d", ErrShadowMismatch, len(parallel.logs), len(serial.logs))
+	}
+
+	parallelReceipts := types.DeriveSha(parallel.receipts, trie.NewStackTrie(nil))
+	serialReceipts := types.DeriveSha(serial.receipts, trie.NewStackTrie(nil))
+
+	if parallelReceipts != serialReceipts {
+		return fmt.Errorf("%w: receipt root parallel %x, serial %x", ErrShadowMismatch, parallelReceipts, serialReceipts)
+	}
+
+	deleteEmptyObjects := config.IsEIP158(block.Number())
+
+	parallelRoot := parallel.statedb.IntermediateRoot(deleteEmptyObjects)
+	serialRoot := serial.statedb.IntermediateRoot(deleteEmptyObjects)
+
+	if parallelRoot != serialRoot {
+		return fmt.Errorf("%w: state root parallel %x, serial %x", ErrShadowMismatch, parallelRoot, serialRoot)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the shadow mode comparison detects any discrepancy between the
+// parallel and serial execution results.
+func TestCompareExecutions(t *testing.T) {
+	t.Parallel()
+
+	newResult := func(parallel bool, balance int64, gas uint64, status uint64, err error) processResult {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		statedb.AddBalance(common.Address{0x01}, big.NewInt(balance))
+
+		receipt := &types.Receipt{Status: status, CumulativeGasUsed: gas, Logs: []*types.Log{}}
+
+		return processResult{
+			receipts: types.Receipts{receipt},
+			usedGas:  gas,
+			err:      err,
+			statedb:  statedb,
+			parallel: parallel,
+		}
+	}
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})
+	failure := errors.New("execution failed")
+
+	tests := []struct {
+		name     string
+		parallel processResult
+		serial   processResult
+		mismatch bool
+	}{
+		{"identical", newResult(true, 1, 21000, 1, nil), newResult(false, 1, 21000, 1, nil), false},
+		{"both failed", newResult(true, 1, 21000, 1, failure), newResult(false, 2, 0, 0, failure), false},
+		{"parallel failed", newResult(true, 1, 21000, 1, failure), newResult(false, 1, 21000, 1, nil), true},
+		{"serial failed", newResult(true, 1, 21000, 1, nil), newResult(false, 1, 21000, 1, failure), true},
+		{"gas used", newResult(true, 1, 21000, 1, nil), newResult(false, 1, 42000, 1, nil), true},
+		{"receipts", newResult(true, 1, 21000, 1, nil), newResult(false, 1, 21000, 0, nil), true},
+		{"state root", newResult(true, 1, 21000, 1, nil), newResult(false, 2, 21000, 1, nil), true},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := compareExecutions(params.TestChainConfig, block, tc.parallel, tc.serial)
+			if have := errors.Is(err, ErrShadowMismatch); have != tc.mismatch {
+				t.Fatalf("mismatch detection mismatch: have %v (%v), want %v", have, err, tc.mismatch)
+			}
+		})
+	}
+}
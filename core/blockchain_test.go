@@ -17,6 +17,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -32,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -212,6 +214,77 @@ func TestParallelBlockChainImport(t *testing.T) {
 	}
 }
 
+// divergingProcessor is a parallel processor mock returning an empty execution
+// result right away, diverging from the expected state of any rewarded block.
+type divergingProcessor struct {
+	done chan struct{}
+	once sync.Once
+}
+
+func (p *divergingProcessor) Process(block *types.Block, statedb *state.StateDB, cfg vm.Config, interruptCtx context.Context) (types.Receipts, []*types.Log, uint64, error) {
+	defer p.once.Do(func() { close(p.done) })
+	return nil, nil, 0, nil
+}
+
+// gatedProcessor delays processing until the gate is closed, ensuring another
+// processor wins the race in ProcessBlock.
+type gatedProcessor struct {
+	Processor
+	gate chan struct{}
+}
+
+func (p *gatedProcessor) Process(block *types.Block, statedb *state.StateDB, cfg vm.Config, interruptCtx context.Context) (types.Receipts, []*types.Log, uint64, error) {
+	<-p.gate
+	return p.Processor.Process(block, statedb, cfg, interruptCtx)
+}
+
+func TestParallelDivergenceFallback(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		fatal bool
+	}{
+		{"serial fallback", false},
+		{"fatal divergence", true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			genDb, _, blockchain, err := newCanonical(ethash.NewFaker(), 0, true)
+			if err != nil {
+				t.Fatalf("failed to create pristine chain: %v", err)
+			}
+			defer blockchain.Stop()
+
+			parallel := &divergingProcessor{done: make(chan struct{})}
+			blockchain.parallelProcessor = parallel
+			blockchain.processor = &gatedProcessor{Processor: blockchain.processor, gate: parallel.done}
+			blockchain.vmConfig.ParallelFatalOnDivergence = tc.fatal
+
+			blocks := makeBlockChain(blockchain.chainConfig, blockchain.GetBlockByHash(blockchain.CurrentBlock().Hash()), 1, ethash.NewFaker(), genDb, canonicalSeed)
+
+			_, err = blockchain.InsertChain(blocks)
+			if tc.fatal && err == nil {
+				t.Fatal("diverging parallel execution imported")
+			}
+
+			if !tc.fatal {
+				if err != nil {
+					t.Fatalf("serial fallback failed: %v", err)
+				}
+
+				if head := blockchain.CurrentBlock().Hash(); head != blocks[0].Hash() {
+					t.Fatalf("head mismatch: have %x, want %x", head, blocks[0].Hash())
+				}
+			}
+		})
+	}
+}
+
 // testHeaderChainImport tries to process a chain of header, writing them into
 // the database if successful.
 func testHeaderChainImport(chain []*types.Header, blockchain *BlockChain) error {
@@ -535,6 +608,119 @@ func testReorgLong(t *testing.T, full bool) {
 	testReorg(t, []int64{0, 0, -9}, []int64{0, 0, 0, -9}, 393280+params.GenesisDifficulty.Int64(), full)
 }
 
+// Tests that re-executing a canonical block reproduces its stored state root.
+func TestRecomputeStateRoot(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		engine  = ethash.NewFaker()
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  GenesisAlloc{address: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 3, func(i int, b *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(b.TxNonce(address), common.Address{0xaa}, big.NewInt(1000), params.TxGas, b.header.BaseFee, nil), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign tx: %v", err)
+		}
+
+		b.AddTx(tx)
+	})
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+
+	for _, block := range blocks {
+		root, parallel, err := chain.RecomputeStateRoot(block)
+		if err != nil {
+			t.Fatalf("block #%d: failed to recompute state root: %v", block.NumberU64(), err)
+		}
+
+		if root != block.Root() {
+			t.Fatalf("block #%d: state root mismatch: have %x, want %x", block.NumberU64(), root, block.Root())
+		}
+
+		if parallel {
+			t.Fatalf("block #%d: serial chain reported parallel execution", block.NumberU64())
+		}
+	}
+}
+
+// Tests that reorgs dropping more canonical blocks than the configured limit
+// are refused, while shallower ones go through.
+func TestReorgDepthLimit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		limit    uint64
+		expected error
+	}{
+		{"unlimited", 0, nil},
+		{"within limit", 4, nil},
+		{"beyond limit", 3, ErrReorgTooDeep},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				engine = ethash.NewFaker()
+				gspec  = &Genesis{
+					BaseFee: big.NewInt(params.InitialBaseFee),
+					Config:  params.AllEthashProtocolChanges,
+				}
+			)
+
+			genDb, canonical, _ := GenerateChainWithGenesis(gspec, engine, 4, nil)
+			fork, _ := GenerateChain(gspec.Config, gspec.ToBlock(), engine, genDb, 5, func(i int, b *BlockGen) {
+				b.SetCoinbase(common.Address{1})
+			})
+
+			cacheConfig := *DefaultCacheConfig
+			cacheConfig.MaxReorgDepth = tc.limit
+
+			chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), &cacheConfig, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("failed to create chain: %v", err)
+			}
+			defer chain.Stop()
+
+			if _, err := chain.InsertChain(canonical); err != nil {
+				t.Fatalf("failed to insert canonical chain: %v", err)
+			}
+
+			// The longer fork drops all the canonical blocks
+			if _, err := chain.InsertChain(fork); !errors.Is(err, tc.expected) {
+				t.Fatalf("error mismatch: have %v, want %v", err, tc.expected)
+			}
+
+			head := fork[len(fork)-1]
+			if tc.expected != nil {
+				head = canonical[len(canonical)-1]
+			}
+
+			if have := chain.CurrentBlock().Hash(); have != head.Hash() {
+				t.Fatalf("head mismatch: have %x, want %x", have, head.Hash())
+			}
+		})
+	}
+}
+
 // Tests that reorganising a short difficult chain after a long easy one
 // overwrites the canonical numbers and links in the database.
 func TestReorgShortHeaders(t *testing.T) { testReorgShort(t, false) }
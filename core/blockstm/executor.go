@@ -168,6 +168,21 @@ type ParallelExecutionResult struct {
 
 const numGoProcs = 1
 
+// MinWorkers is the smallest execution worker limit the executor can honour:
+// the worker of the non-speculative tasks plus a speculative one.
+const MinWorkers = numGoProcs + 1
+
+// LimitSpeculativeProcs caps the given number of speculative workers so that
+// the executor runs at most maxWorkers execution workers in total. A maxWorkers
+// of zero leaves the number of speculative workers untouched.
+func LimitSpeculativeProcs(numProcs int, maxWorkers int) int {
+	if maxWorkers > 0 && numProcs+numGoProcs > maxWorkers {
+		return maxWorkers - numGoProcs
+	}
+
+	return numProcs
+}
+
 type ParallelExecutor struct {
 	tasks []ExecTask
 
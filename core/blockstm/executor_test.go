@@ -986,3 +986,49 @@ func TestBreakFromPartialCircularDependency(t *testing.T) {
 		t.Error("Expected cancel error")
 	}
 }
+
+func TestLimitSpeculativeProcs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		procs      int
+		maxWorkers int
+		expected   int
+	}{
+		{8, 0, 8},
+		{8, 16, 8},
+		{8, 9, 8},
+		{8, 4, 3},
+		{8, MinWorkers, 1},
+	}
+
+	for _, tc := range testCases {
+		if have := LimitSpeculativeProcs(tc.procs, tc.maxWorkers); have != tc.expected {
+			t.Errorf("speculative procs mismatch for %d procs, %d workers: have %d, want %d", tc.procs, tc.maxWorkers, have, tc.expected)
+		}
+	}
+}
+
+func BenchmarkExecuteParallelMaxWorkers(b *testing.B) {
+	for _, maxWorkers := range []int{MinWorkers, 4, 0} {
+		procs := LimitSpeculativeProcs(numProcs, maxWorkers)
+
+		b.Run(fmt.Sprintf("maxworkers=%d", maxWorkers), func(b *testing.B) {
+			rand.Seed(0)
+
+			sender := func(i int) common.Address {
+				return common.BigToAddress(big.NewInt(int64(i % 20)))
+			}
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				tasks, _ := taskFactory(200, sender, 20, 20, 100, randomPathGenerator, readTime, writeTime, nonIOTime)
+				b.StartTimer()
+
+				if _, err := ExecuteParallel(tasks, false, false, procs, nil); err != nil {
+					b.Fatalf("parallel execution failed: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -467,6 +467,23 @@ func (c *ChainIndexer) Sections() (uint64, uint64, common.Hash) {
 	return c.storedSections, c.storedSections*c.sectionSize - 1, c.SectionHead(c.storedSections - 1)
 }
 
+// SectionSize returns the number of blocks in a single indexed section.
+func (c *ChainIndexer) SectionSize() uint64 {
+	return c.sectionSize
+}
+
+// Update notifies the indexer about the given chain head and triggers the
+// processing of any completed but not yet indexed sections, e.g. to catch up
+// after a large import.
+func (c *ChainIndexer) Update(head uint64) {
+	c.newHead(head, false)
+
+	select {
+	case c.update <- struct{}{}:
+	default:
+	}
+}
+
 // AddChildIndexer adds a child ChainIndexer that can use the output of this one
 func (c *ChainIndexer) AddChildIndexer(indexer *ChainIndexer) {
 	if indexer == c {
@@ -32,6 +32,22 @@ var (
 	// ErrNoGenesis is returned when there is no Genesis Block.
 	ErrNoGenesis = errors.New("genesis not found in chain")
 
+	// ErrReorgTooDeep is returned when a chain reorg would drop more canonical
+	// blocks than the configured limit.
+	ErrReorgTooDeep = errors.New("chain reorg too deep")
+
+	// ErrInvalidParallelWorkers is returned when the parallel EVM worker limit
+	// can't be honoured by the parallel executor.
+	ErrInvalidParallelWorkers = errors.New("invalid parallel EVM worker limit")
+
+	// ErrShadowMismatch is reported in parallel EVM shadow mode if the parallel
+	// and serial execution of a block yield different results.
+	ErrShadowMismatch = errors.New("parallel and serial execution mismatch")
+
+	// ErrInsertPaused is returned when block import is paused, e.g. because the
+	// node is running out of disk space.
+	ErrInsertPaused = errors.New("block import paused")
+
 	errSideChainReceipts = errors.New("side blocks can't be accepted as ancient chain data")
 )
 
@@ -46,7 +46,10 @@ import (
 //go:embed allocs
 var allocs embed.FS
 
-var errGenesisNoConfig = errors.New("genesis has no chain configuration")
+var (
+	errGenesisNoConfig = errors.New("genesis has no chain configuration")
+	errGenesisNotFound = errors.New("genesis block not found in database")
+)
 
 // Genesis specifies the header fields, state of a genesis block. It also defines hard
 // fork switch-over blocks through the chain configuration.
@@ -296,6 +299,29 @@ func (e *GenesisMismatchError) Error() string {
 	return fmt.Sprintf("database contains incompatible genesis (have %x, new %x)", e.Stored, e.New)
 }
 
+// OverrideConflictError is raised when a fork override would move the boundary
+// of a fork that the local chain head has already passed.
+type OverrideConflictError struct {
+	Fork        string
+	Stored, New *uint64 // Fork timestamps of the stored config and the override
+	Head        uint64  // Number of the local chain head
+	HeadTime    uint64  // Timestamp of the local chain head
+}
+
+func (e *OverrideConflictError) Error() string {
+	return fmt.Sprintf("override of %s fork from %s to %s conflicts with local chain head #%d (timestamp %d) already past the fork boundary, drop the override or rewind the chain",
+		e.Fork, forkTimeString(e.Stored), forkTimeString(e.New), e.Head, e.HeadTime)
+}
+
+// forkTimeString formats an optional fork timestamp.
+func forkTimeString(time *uint64) string {
+	if time == nil {
+		return "unset"
+	}
+
+	return fmt.Sprintf("timestamp %d", *time)
+}
+
 // ChainOverrides contains the changes to chain config.
 type ChainOverrides struct {
 	OverrideShanghai *uint64
@@ -397,6 +423,15 @@ func SetupGenesisBlockWithOverride(db ethdb.Database, triedb *trie.Database, gen
 		return newcfg, stored, nil
 	}
 
+	// Report overrides moving an already passed fork explicitly, instead of the
+	// generic compatibility error. This must happen before the overrides may be
+	// applied onto the stored config below.
+	if head := rawdb.ReadHeadHeader(db); head != nil {
+		if err := checkOverrides(storedcfg, overrides, head); err != nil {
+			return newcfg, stored, err
+		}
+	}
+
 	// nolint:errchkjson
 	storedData, _ := json.Marshal(storedcfg)
 	// Special case: if a private network is being used (no genesis and also no
@@ -429,6 +464,68 @@ func SetupGenesisBlockWithOverride(db ethdb.Database, triedb *trie.Database, gen
 	return newcfg, stored, nil
 }
 
+// LoadChainConfig loads the chain config stored along the genesis block without
+// writing to the database, e.g. if it's opened read-only. The overrides are only
+// applied in memory. If a genesis is given, it must match the stored one.
+func LoadChainConfig(db ethdb.Database, genesis *Genesis, overrides *ChainOverrides) (*params.ChainConfig, common.Hash, error) {
+	if genesis != nil && genesis.Config == nil {
+		return params.AllEthashProtocolChanges, common.Hash{}, errGenesisNoConfig
+	}
+
+	stored := rawdb.ReadCanonicalHash(db, 0)
+	if (stored == common.Hash{}) {
+		return nil, common.Hash{}, errGenesisNotFound
+	}
+
+	if genesis != nil {
+		hash := genesis.ToBlock().Hash()
+		if hash != stored {
+			return genesis.Config, hash, &GenesisMismatchError{stored, hash}
+		}
+	}
+
+	config := rawdb.ReadChainConfig(db, stored)
+	if config == nil {
+		config = genesis.configOrDefault(stored)
+	}
+
+	if overrides != nil && overrides.OverrideShanghai != nil {
+		config.ShanghaiTime = overrides.OverrideShanghai
+	}
+
+	return config, stored, nil
+}
+
+// checkOverrides ensures the chain config overrides don't move the boundary of a
+// fork the given head has already passed.
+func checkOverrides(stored *params.ChainConfig, overrides *ChainOverrides, head *types.Header) error {
+	if overrides == nil || head.Number.Uint64() == 0 {
+		return nil
+	}
+
+	if override := overrides.OverrideShanghai; override != nil && forkTimeConflict(stored.ShanghaiTime, override, head.Time) {
+		return &OverrideConflictError{
+			Fork:     "Shanghai",
+			Stored:   stored.ShanghaiTime,
+			New:      override,
+			Head:     head.Number.Uint64(),
+			HeadTime: head.Time,
+		}
+	}
+
+	return nil
+}
+
+// forkTimeConflict reports whether changing a fork timestamp from stored to
+// override affects blocks up to the given head timestamp.
+func forkTimeConflict(stored, override *uint64, head uint64) bool {
+	if stored != nil && override != nil && *stored == *override {
+		return false
+	}
+
+	return (stored != nil && *stored <= head) || (override != nil && *override <= head)
+}
+
 // LoadCliqueConfig loads the stored clique config if the chain config
 // is already present in database, otherwise, return the config in the
 // provided genesis specification. Note the returned clique config can
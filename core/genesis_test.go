@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/params"
@@ -167,6 +168,71 @@ func TestSetupGenesis(t *testing.T) {
 	}
 }
 
+// TestSetupGenesisOverrideConflict checks that a Shanghai override moving a fork
+// boundary the local chain has already passed is reported explicitly.
+func TestSetupGenesisOverrideConflict(t *testing.T) {
+	t.Parallel()
+
+	u64 := func(v uint64) *uint64 { return &v }
+
+	tests := []struct {
+		name     string
+		stored   *uint64
+		override *uint64
+		wantErr  error
+	}{
+		{
+			name:     "override moving a passed fork",
+			stored:   u64(500),
+			override: u64(2000),
+			wantErr:  &OverrideConflictError{Fork: "Shanghai", Stored: u64(500), New: u64(2000), Head: 10, HeadTime: 1000},
+		},
+		{
+			name:     "override activating a fork in the past",
+			stored:   nil,
+			override: u64(800),
+			wantErr:  &OverrideConflictError{Fork: "Shanghai", Stored: nil, New: u64(800), Head: 10, HeadTime: 1000},
+		},
+		{
+			name:     "override matching the stored fork",
+			stored:   u64(500),
+			override: u64(500),
+		},
+		{
+			name:     "override moving a future fork",
+			stored:   u64(5000),
+			override: u64(6000),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := *params.AllEthashProtocolChanges
+			config.ShanghaiTime = test.stored
+
+			var (
+				db      = rawdb.NewMemoryDatabase()
+				genesis = &Genesis{Config: &config, BaseFee: big.NewInt(params.InitialBaseFee)}
+				block   = genesis.MustCommit(db)
+			)
+
+			// Advance the local head past the stored fork without executing blocks
+			head := &types.Header{ParentHash: block.Hash(), Number: big.NewInt(10), Time: 1000}
+			rawdb.WriteHeader(db, head)
+			rawdb.WriteCanonicalHash(db, head.Hash(), 10)
+			rawdb.WriteHeadHeaderHash(db, head.Hash())
+
+			_, _, err := SetupGenesisBlockWithOverride(db, trie.NewDatabase(db), genesis, &ChainOverrides{OverrideShanghai: test.override})
+			if !reflect.DeepEqual(err, test.wantErr) {
+				t.Fatalf("error mismatch: have %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
 // TestGenesisHashes checks the congruity of default genesis data to
 // corresponding hardcoded genesis hash values.
 func TestGenesisHashes(t *testing.T) {
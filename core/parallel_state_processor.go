@@ -38,6 +38,9 @@ import (
 type ParallelEVMConfig struct {
 	Enable               bool
 	SpeculativeProcesses int
+	FatalOnDivergence    bool
+	MaxWorkers           int
+	ShadowVerify         bool
 }
 
 // StateProcessor is a basic Processor, which takes care of transitioning
@@ -364,7 +367,8 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 	backupStateDB := statedb.Copy()
 
 	profile := false
-	result, err := blockstm.ExecuteParallel(tasks, profile, metadata, cfg.ParallelSpeculativeProcesses, interruptCtx)
+	procs := blockstm.LimitSpeculativeProcs(cfg.ParallelSpeculativeProcesses, cfg.ParallelMaxWorkers)
+	result, err := blockstm.ExecuteParallel(tasks, profile, metadata, procs, interruptCtx)
 
 	if err == nil && profile && result.Deps != nil {
 		_, weight := result.Deps.LongestPath(*result.Stats)
@@ -398,7 +402,7 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 				t.totalUsedGas = usedGas
 			}
 
-			_, err = blockstm.ExecuteParallel(tasks, false, metadata, cfg.ParallelSpeculativeProcesses, interruptCtx)
+			_, err = blockstm.ExecuteParallel(tasks, false, metadata, procs, interruptCtx)
 
 			break
 		}
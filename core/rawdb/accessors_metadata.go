@@ -201,3 +201,60 @@ func WriteTransitionStatus(db ethdb.KeyValueWriter, data []byte) {
 		log.Crit("Failed to store the eth2 transition status", "err", err)
 	}
 }
+
+// ReadPruningScheduled retrieves if trie pruning is scheduled to be re-enabled
+// on the next startup.
+func ReadPruningScheduled(db ethdb.KeyValueReader) bool {
+	scheduled, _ := db.Has(pruningScheduledKey)
+	return scheduled
+}
+
+// WritePruningScheduled stores the flag re-enabling trie pruning on the next startup.
+func WritePruningScheduled(db ethdb.KeyValueWriter) {
+	if err := db.Put(pruningScheduledKey, []byte("42")); err != nil {
+		log.Crit("Failed to store pruning scheduled flag", "err", err)
+	}
+}
+
+// DeletePruningScheduled deletes the flag re-enabling trie pruning on the next startup.
+func DeletePruningScheduled(db ethdb.KeyValueWriter) {
+	if err := db.Delete(pruningScheduledKey); err != nil {
+		log.Crit("Failed to remove pruning scheduled flag", "err", err)
+	}
+}
+
+// whitelistedCheckpoint is the end block of a whitelisted bor checkpoint.
+type whitelistedCheckpoint struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+// ReadLastWhitelistedCheckpoint retrieves the end block number and hash of the
+// latest whitelisted checkpoint, if any was stored.
+func ReadLastWhitelistedCheckpoint(db ethdb.KeyValueReader) (uint64, common.Hash, bool) {
+	data, _ := db.Get(lastWhitelistedCheckpointKey)
+	if len(data) == 0 {
+		return 0, common.Hash{}, false
+	}
+
+	var checkpoint whitelistedCheckpoint
+	if err := rlp.DecodeBytes(data, &checkpoint); err != nil {
+		log.Warn("Invalid whitelisted checkpoint entry", "err", err)
+		return 0, common.Hash{}, false
+	}
+
+	return checkpoint.Number, checkpoint.Hash, true
+}
+
+// WriteLastWhitelistedCheckpoint stores the end block number and hash of the
+// latest whitelisted checkpoint.
+func WriteLastWhitelistedCheckpoint(db ethdb.KeyValueWriter, number uint64, hash common.Hash) {
+	data, err := rlp.EncodeToBytes(whitelistedCheckpoint{Number: number, Hash: hash})
+	if err != nil {
+		log.Crit("Failed to encode whitelisted checkpoint", "err", err)
+	}
+
+	if err := db.Put(lastWhitelistedCheckpointKey, data); err != nil {
+		log.Crit("Failed to store whitelisted checkpoint", "err", err)
+	}
+}
@@ -84,6 +84,14 @@ var (
 	// transitionStatusKey tracks the eth2 transition status.
 	transitionStatusKey = []byte("eth2-transition")
 
+	// pruningScheduledKey flags that trie pruning should be re-enabled on an
+	// archive node at the next startup.
+	pruningScheduledKey = []byte("PruningScheduled")
+
+	// lastWhitelistedCheckpointKey tracks the end block of the latest bor
+	// checkpoint whitelisted from heimdall.
+	lastWhitelistedCheckpointKey = []byte("LastWhitelistedCheckpoint")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td
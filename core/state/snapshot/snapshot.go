@@ -895,6 +895,23 @@ func (t *Tree) generating() (bool, error) {
 	return layer.genMarker != nil, nil
 }
 
+// Generation returns the root of the disk layer along with its generation
+// marker, which is nil once the snapshot is fully generated.
+func (t *Tree) Generation() (common.Hash, []byte, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	layer := t.disklayer()
+	if layer == nil {
+		return common.Hash{}, nil, errors.New("disk layer is missing")
+	}
+
+	layer.lock.RLock()
+	defer layer.lock.RUnlock()
+
+	return layer.root, common.CopyBytes(layer.genMarker), nil
+}
+
 // DiskRoot is a external helper function to return the disk layer root.
 func (t *Tree) DiskRoot() common.Hash {
 	t.lock.Lock()
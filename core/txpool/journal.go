@@ -21,10 +21,12 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -32,6 +34,10 @@ import (
 // into the journal, but no such file is currently open.
 var errNoActiveJournal = errors.New("no active journal")
 
+// journalRotateTimer measures how long regenerating the journal takes, which
+// stalls the pool on slow disks.
+var journalRotateTimer = metrics.NewRegisteredTimer("txpool/journal/rotate", nil)
+
 // devNull is a WriteCloser that just discards anything written into it. Its
 // goal is to allow the transaction journal to write into a fake journal when
 // loading transactions on startup without printing warnings due to no file
@@ -46,6 +52,16 @@ func (*devNull) Close() error                      { return nil }
 type journal struct {
 	path   string         // Filesystem path to store the transactions at
 	writer io.WriteCloser // Output stream to write new transactions into
+
+	lastRotation time.Time // Time the journal was last regenerated
+	entries      int       // Number of transactions in the journal
+}
+
+// JournalStatus describes the local transaction journal of the pool.
+type JournalStatus struct {
+	Path         string    `json:"path"`
+	LastRotation time.Time `json:"lastRotation"`
+	Entries      int       `json:"entries"`
 }
 
 // newTxJournal creates a new transaction journal to
@@ -134,12 +150,16 @@ func (journal *journal) insert(tx *types.Transaction) error {
 		return err
 	}
 
+	journal.entries++
+
 	return nil
 }
 
 // rotate regenerates the transaction journal based on the current contents of
 // the transaction pool.
 func (journal *journal) rotate(all map[common.Address]types.Transactions) error {
+	start := time.Now()
+
 	// Close the current journal (if any is open)
 	if journal.writer != nil {
 		if err := journal.writer.Close(); err != nil {
@@ -180,12 +200,25 @@ func (journal *journal) rotate(all map[common.Address]types.Transactions) error
 	}
 
 	journal.writer = sink
+	journal.lastRotation = time.Now()
+	journal.entries = journaled
+
+	journalRotateTimer.UpdateSince(start)
 
-	log.Info("Regenerated local transaction journal", "transactions", journaled, "accounts", len(all))
+	log.Info("Regenerated local transaction journal", "transactions", journaled, "accounts", len(all), "elapsed", common.PrettyDuration(time.Since(start)))
 
 	return nil
 }
 
+// status returns the path, last rotation time and size of the journal.
+func (journal *journal) status() *JournalStatus {
+	return &JournalStatus{
+		Path:         journal.path,
+		LastRotation: journal.lastRotation,
+		Entries:      journal.entries,
+	}
+}
+
 // close flushes the transaction journal contents to disk and closes the file.
 func (journal *journal) close() error {
 	var err error
@@ -101,6 +101,10 @@ var (
 	// ErrOverdraft is returned if a transaction would cause the senders balance to go negative
 	// thus invalidating a potential large number of transactions.
 	ErrOverdraft = errors.New("transaction would cause overdraft")
+
+	// ErrTxFiltered is returned if a transaction is rejected by the TxFilter
+	// configured for the pool.
+	ErrTxFiltered = errors.New("transaction rejected by filter")
 )
 
 var (
@@ -128,6 +132,7 @@ var (
 	invalidTxMeter     = metrics.NewRegisteredMeter("txpool/invalid", nil)
 	underpricedTxMeter = metrics.NewRegisteredMeter("txpool/underpriced", nil)
 	overflowedTxMeter  = metrics.NewRegisteredMeter("txpool/overflowed", nil)
+	filteredTxMeter    = metrics.NewRegisteredMeter("txpool/filtered", nil)
 
 	// throttleTxMeter counts how many transactions are rejected due to too-many-changes between
 	// txpool reorgs.
@@ -187,7 +192,9 @@ type Config struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime            time.Duration // Maximum amount of time non-executable transaction are queued
-	AllowUnprotectedTxs bool          // Allow non-EIP-155 transactions
+	AllowUnprotectedTxs bool          // Allow non-EIP-155 local transactions, e.g. submitted over RPC
+
+	AllowUnprotectedRemoteTxs bool // Allow non-EIP-155 transactions received from the network
 }
 
 // DefaultConfig contains the default configurations for the transaction
@@ -308,6 +315,23 @@ type TxPool struct {
 	changesSinceReorg int // A counter for how many drops we've performed in-between reorg.
 
 	promoteTxCh chan struct{} // should be used only for tests
+
+	filter TxFilter // Optional filter rejecting transactions before they enter the pool
+}
+
+// TxFilter decides whether a transaction may enter the pool, e.g. to reject
+// transactions to or from certain addresses. It's consulted for both local and
+// remote transactions, after the basic validity checks passed.
+type TxFilter interface {
+	// FilterTx returns a non-nil error if the transaction must be rejected.
+	FilterTx(tx *types.Transaction, from common.Address) error
+}
+
+// WithTxFilter installs a filter on the transaction acceptance path of the pool.
+func WithTxFilter(filter TxFilter) func(pool *TxPool) {
+	return func(pool *TxPool) {
+		pool.filter = filter
+	}
 }
 
 type txpoolResetRequest struct {
@@ -716,6 +740,34 @@ func (pool *TxPool) Locals() []common.Address {
 	return pool.locals.flatten()
 }
 
+// AddLocalAccount marks the given account as local, exempting its transactions
+// from the pricing and eviction rules. Any remote transactions already pooled
+// from the account are migrated into the local set.
+func (pool *TxPool) AddLocalAccount(addr common.Address) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.locals.contains(addr) {
+		return
+	}
+
+	log.Info("Setting new local account", "address", addr)
+	pool.locals.add(addr)
+	pool.priced.Removed(pool.all.RemoteToLocals(pool.locals)) // Migrate the remotes if it's marked as local first time.
+}
+
+// RemoveLocalAccount stops treating the given account as local. Transactions
+// already pooled from the account keep their local status until they are
+// either included or dropped.
+func (pool *TxPool) RemoveLocalAccount(addr common.Address) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.locals.remove(addr) {
+		log.Info("Removed local account", "address", addr)
+	}
+}
+
 // local retrieves all currently known local transactions, grouped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -740,6 +792,27 @@ func (pool *TxPool) local() map[common.Address]types.Transactions {
 	return txs
 }
 
+// allowUnprotected reports whether non-EIP-155 transactions are accepted from the
+// given origin: locally submitted ones or ones received from the network.
+func (pool *TxPool) allowUnprotected(local bool) bool {
+	if local {
+		return pool.config.AllowUnprotectedTxs
+	}
+
+	return pool.config.AllowUnprotectedRemoteTxs
+}
+
+// senderSigner returns the signer to derive the sender of a transaction from the
+// given origin with, which skips the chain id check if unprotected transactions
+// are allowed from it.
+func (pool *TxPool) senderSigner(tx *types.Transaction, local bool) types.Signer {
+	if pool.allowUnprotected(local) {
+		return types.NewFakeSigner(tx.ChainId())
+	}
+
+	return pool.signer
+}
+
 // validateTxBasics checks whether a transaction is valid according to the consensus
 // rules, but does not check state-dependent validation such as sufficient balance.
 // This check is meant as an early check which only needs to be performed once,
@@ -796,9 +869,10 @@ func (pool *TxPool) validateTxBasics(tx *types.Transaction, local bool) error {
 		return core.ErrTipAboveFeeCap
 	}
 
-	// Make sure the transaction is signed properly.
-	from, err := types.Sender(pool.signer, tx)
-	if err != nil && !pool.config.AllowUnprotectedTxs {
+	// Make sure the transaction is signed properly. The sender is cached for the
+	// later lookups with the pool signer.
+	from, err := types.Sender(pool.senderSigner(tx, local), tx)
+	if err != nil && !pool.allowUnprotected(local) {
 		return ErrInvalidSender
 	}
 
@@ -888,6 +962,22 @@ func (pool *TxPool) validateTx(tx *types.Transaction, _ bool) error {
 	return nil
 }
 
+// filterTx checks the transaction against the configured TxFilter, if any. The
+// sender has already been recovered and cached by validateTxBasics.
+func (pool *TxPool) filterTx(tx *types.Transaction, local bool) error {
+	if pool.filter == nil {
+		return nil
+	}
+
+	from, _ := types.Sender(pool.senderSigner(tx, local), tx)
+	if err := pool.filter.FilterTx(tx, from); err != nil {
+		log.Trace("Discarding filtered transaction", "hash", tx.Hash(), "from", from, "err", err)
+		return fmt.Errorf("%w: %v", ErrTxFiltered, err)
+	}
+
+	return nil
+}
+
 // add validates a transaction and inserts it into the non-executable queue for later
 // pending promotion and execution. If the transaction is a replacement for an already
 // pending or queued one, it overwrites the previous transaction if its price is higher.
@@ -1098,6 +1188,19 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction, local boo
 	return old != nil, nil
 }
 
+// JournalStatus returns the status of the local transaction journal, or nil if
+// journaling is disabled.
+func (pool *TxPool) JournalStatus() *JournalStatus {
+	if pool.journal == nil {
+		return nil
+	}
+
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return pool.journal.status()
+}
+
 // journalTx adds the specified transaction to the local disk journal if it is
 // deemed to have been sent from a local account.
 func (pool *TxPool) journalTx(from common.Address, tx *types.Transaction) {
@@ -1247,8 +1350,12 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 			continue
 		}
 
-		if pool.config.AllowUnprotectedTxs {
-			pool.signer = types.NewFakeSigner(tx.ChainId())
+		if err := pool.filterTx(tx, local); err != nil {
+			errs[i] = err
+
+			filteredTxMeter.Mark(1)
+
+			continue
 		}
 
 		// Accumulate all unknown transactions for deeper processing
@@ -1306,16 +1413,18 @@ func (pool *TxPool) addTx(tx *types.Transaction, local, sync bool) error {
 		// Exclude transactions with invalid signatures as soon as
 		// possible and cache senders in transactions before
 		// obtaining lock
-		if pool.config.AllowUnprotectedTxs {
-			pool.signer = types.NewFakeSigner(tx.ChainId())
-		}
-
-		_, err = types.Sender(pool.signer, tx)
+		_, err = types.Sender(pool.senderSigner(tx, local), tx)
 		if err != nil {
 			invalidTxMeter.Mark(1)
 
 			return
 		}
+
+		if err = pool.filterTx(tx, local); err != nil {
+			filteredTxMeter.Mark(1)
+
+			return
+		}
 	}()
 
 	if err != nil {
@@ -1513,6 +1622,14 @@ func (pool *TxPool) removeTx(hash common.Hash, outofbound bool) int {
 	return 0
 }
 
+// Reconcile resets the pool to the given head block and waits for it to finish,
+// revalidating all transactions against its state. If the previous head is
+// given, the transactions of the blocks it had but newHead hasn't are
+// reinjected, as on a regular chain reorg.
+func (pool *TxPool) Reconcile(oldHead, newHead *types.Header) {
+	<-pool.requestReset(oldHead, newHead)
+}
+
 // requestReset requests a pool reset to the new head block.
 // The returned channel is closed when the reset has occurred.
 func (pool *TxPool) requestReset(oldHead *types.Header, newHead *types.Header) chan struct{} {
@@ -2417,6 +2534,32 @@ func (as *accountSet) add(addr common.Address) {
 	as.accounts[addr] = struct{}{}
 }
 
+// remove deletes an address from the set, returning whether it was present.
+func (as *accountSet) remove(addr common.Address) bool {
+	as.m.Lock()
+	defer as.m.Unlock()
+
+	if _, ok := as.accounts[addr]; !ok {
+		return false
+	}
+
+	delete(as.accounts, addr)
+
+	// The flattened slice may be shared with callers, so rebuild it instead
+	// of filtering in place.
+	flatted := make([]common.Address, 0, len(as.accounts))
+
+	for _, account := range as.accountsFlatted {
+		if account != addr {
+			flatted = append(flatted, account)
+		}
+	}
+
+	as.accountsFlatted = flatted
+
+	return true
+}
+
 // addTx adds the sender of tx into the set.
 func (as *accountSet) addTx(tx *types.Transaction) {
 	if addr, err := types.Sender(as.signer, tx); err == nil {
@@ -25,6 +25,7 @@ import (
 	"math/big"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
@@ -48,6 +49,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
 )
@@ -378,6 +380,54 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// testTxFilter rejects all transactions from the listed senders.
+type testTxFilter map[common.Address]bool
+
+func (f testTxFilter) FilterTx(tx *types.Transaction, from common.Address) error {
+	if f[from] {
+		return fmt.Errorf("sender %v blocked", from)
+	}
+
+	return nil
+}
+
+// Tests that transactions rejected by the configured filter never enter the
+// pool, while the others are accepted as usual.
+func TestTxFilter(t *testing.T) {
+	t.Parallel()
+
+	blocked, _ := crypto.GenerateKey()
+	pool, key := setupPoolWithConfig(params.TestChainConfig, testTxPoolConfig, txPoolGasLimit, WithTxFilter(testTxFilter{crypto.PubkeyToAddress(blocked.PublicKey): true}))
+	defer pool.Stop()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(blocked.PublicKey), big.NewInt(1000000))
+
+	filtered := filteredTxMeter.Count()
+
+	if err := pool.AddRemote(transaction(0, 100000, blocked)); !errors.Is(err, ErrTxFiltered) {
+		t.Fatalf("remote error mismatch: have %v, want %v", err, ErrTxFiltered)
+	}
+
+	if err := pool.AddLocal(transaction(1, 100000, blocked)); !errors.Is(err, ErrTxFiltered) {
+		t.Fatalf("local error mismatch: have %v, want %v", err, ErrTxFiltered)
+	}
+
+	if err := pool.AddRemote(transaction(0, 100000, key)); err != nil {
+		t.Fatalf("failed to add unfiltered transaction: %v", err)
+	}
+
+	if pending, queued := pool.Stats(); pending+queued != 1 {
+		t.Fatalf("pooled transactions mismatch: have %d, want %d", pending+queued, 1)
+	}
+
+	if metrics.Enabled {
+		if have := filteredTxMeter.Count() - filtered; have != 2 {
+			t.Fatalf("filtered meter mismatch: have %d, want %d", have, 2)
+		}
+	}
+}
+
 func TestQueue(t *testing.T) {
 	t.Parallel()
 
@@ -526,6 +576,60 @@ func TestChainFork(t *testing.T) {
 	}
 }
 
+func TestReconcile(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Stop()
+
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	reconcile := func(nonce uint64) {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		statedb.AddBalance(addr, big.NewInt(100000000000000))
+		statedb.SetNonce(addr, nonce)
+
+		chain := newTestBlockChain(1000000, statedb, new(event.Feed))
+		pool.chain = chain
+		pool.Reconcile(nil, chain.CurrentBlock())
+	}
+	reconcile(0)
+
+	for nonce := uint64(0); nonce < 4; nonce++ {
+		if err := pool.AddRemotesSync([]*types.Transaction{transaction(nonce, 100000, key)})[0]; err != nil {
+			t.Fatalf("failed to add transaction %d: %v", nonce, err)
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		nonce   uint64
+		pending int
+		queued  int
+		next    uint64
+	}{
+		// The new head included the first two transactions
+		{"head advanced", 2, 2, 0, 4},
+		// The chain got rewound before them, leaving a nonce gap
+		{"head rewound", 0, 0, 2, 0},
+	}
+
+	for _, tc := range testCases {
+		reconcile(tc.nonce)
+
+		if pending, queued := pool.Stats(); pending != tc.pending || queued != tc.queued {
+			t.Fatalf("%s: pool size mismatch: have %d/%d, want %d/%d", tc.name, pending, queued, tc.pending, tc.queued)
+		}
+
+		if next := pool.Nonce(addr); next != tc.next {
+			t.Fatalf("%s: nonce mismatch: have %d, want %d", tc.name, next, tc.next)
+		}
+
+		if err := validatePoolInternals(pool); err != nil {
+			t.Fatalf("%s: pool internal state corrupted: %v", tc.name, err)
+		}
+	}
+}
+
 func TestDoubleNonce(t *testing.T) {
 	t.Parallel()
 
@@ -1040,7 +1144,7 @@ func TestRejectUnprotectedTransaction(t *testing.T) {
 	}
 }
 
-// Test that txpool allows unprotected txs when AllowUnprotectedTxs flag is set
+// Test that txpool allows unprotected txs when AllowUnprotectedRemoteTxs flag is set
 // FIXME: The below test causes some tests to fail randomly (probably due to parallel execution)
 //
 //nolint:paralleltest
@@ -1054,7 +1158,7 @@ func TestAllowUnprotectedTransactionWhenSet(t *testing.T) {
 	from := crypto.PubkeyToAddress(key.PublicKey)
 
 	// Allow unprotected txs
-	pool.config.AllowUnprotectedTxs = true
+	pool.config.AllowUnprotectedRemoteTxs = true
 	pool.chainconfig.ChainID = big.NewInt(5)
 	pool.signer = types.LatestSignerForChainID(pool.chainconfig.ChainID)
 	testAddBalance(pool, from, big.NewInt(0xffffffffffffff))
@@ -1064,6 +1168,61 @@ func TestAllowUnprotectedTransactionWhenSet(t *testing.T) {
 	}
 }
 
+// Tests that unprotected transactions are accepted from local submissions and
+// from the network independently, depending on the respective flags.
+func TestUnprotectedTransactionOrigins(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		allowLocal  bool
+		allowRemote bool
+	}{
+		{"none allowed", false, false},
+		{"local allowed", true, false},
+		{"remote allowed", false, true},
+		{"both allowed", true, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := testTxPoolConfig
+			config.AllowUnprotectedTxs = tc.allowLocal
+			config.AllowUnprotectedRemoteTxs = tc.allowRemote
+
+			pool, key := setupPoolWithConfig(params.TestChainConfig, config, txPoolGasLimit)
+			defer pool.Stop()
+
+			testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(0xffffffffffffff))
+
+			// Transactions signed for another chain can't be replay protected
+			foreign := big.NewInt(5)
+			sign := func(nonce uint64) *types.Transaction {
+				return types.MustSignNewTx(key, types.LatestSignerForChainID(foreign), &types.DynamicFeeTx{
+					ChainID:   foreign,
+					Nonce:     nonce,
+					GasTipCap: big.NewInt(2),
+					GasFeeCap: big.NewInt(5),
+					Gas:       22000,
+					To:        &common.Address{},
+					Value:     big.NewInt(100),
+				})
+			}
+
+			if err := pool.AddLocal(sign(0)); (err == nil) != tc.allowLocal {
+				t.Errorf("local acceptance mismatch: have err %v, want allowed %v", err, tc.allowLocal)
+			}
+
+			if err := pool.AddRemote(sign(1)); (err == nil) != tc.allowRemote {
+				t.Errorf("remote acceptance mismatch: have err %v, want allowed %v", err, tc.allowRemote)
+			}
+		})
+	}
+}
+
 // Tests that if the transaction count belonging to multiple accounts go above
 // some threshold, the higher transactions are dropped to prevent DOS attacks.
 //
@@ -1962,6 +2121,50 @@ func TestRepricingKeepsLocals(t *testing.T) {
 	validate()
 }
 
+// Tests that accounts can be dynamically marked as local and unmarked again, and
+// that pooled remote transactions are migrated when an account becomes local.
+func TestLocalAccountManagement(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(1000000, statedb, new(event.Feed))
+
+	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
+	defer pool.Stop()
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, addr, big.NewInt(1000000000))
+
+	if err := pool.AddRemotesSync([]*types.Transaction{pricedTransaction(0, 100000, big.NewInt(1), key)}); err[0] != nil {
+		t.Fatalf("failed to add remote transaction: %v", err[0])
+	}
+
+	if locals := pool.all.LocalCount(); locals != 0 {
+		t.Fatalf("local transaction count mismatch: have %d, want %d", locals, 0)
+	}
+
+	pool.AddLocalAccount(addr)
+
+	if locals := pool.Locals(); len(locals) != 1 || locals[0] != addr {
+		t.Fatalf("local accounts mismatch: have %v, want %v", locals, []common.Address{addr})
+	}
+
+	if locals := pool.all.LocalCount(); locals != 1 {
+		t.Fatalf("local transaction count mismatch: have %d, want %d", locals, 1)
+	}
+
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+
+	pool.RemoveLocalAccount(addr)
+
+	if locals := pool.Locals(); len(locals) != 0 {
+		t.Fatalf("local accounts mismatch: have %v, want none", locals)
+	}
+}
+
 // Tests that when the pool reaches its global transaction limit, underpriced
 // transactions are gradually shifted out for more expensive ones and any gapped
 // pending transactions are moved into the queue.
@@ -2641,6 +2844,54 @@ func TestReplacementDynamicFee(t *testing.T) {
 
 // Tests that local transactions are journaled to disk, but remote transactions
 // get discarded between restarts.
+// Tests that the journal status tracks the journaled local transactions and
+// the regeneration of the journal.
+func TestJournalStatus(t *testing.T) {
+	t.Parallel()
+
+	journal := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.Journal = journal
+	config.Rejournal = time.Hour
+
+	pool := NewTxPool(config, params.TestChainConfig, blockchain)
+	defer pool.Stop()
+
+	status := pool.JournalStatus()
+	if status == nil {
+		t.Fatalf("journal status missing")
+	}
+
+	if status.Path != journal || status.Entries != 0 || status.LastRotation.IsZero() {
+		t.Fatalf("initial status mismatch: have %+v, want path %s, no entries and a rotation", status, journal)
+	}
+
+	local, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(local.PublicKey), big.NewInt(1000000000))
+
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		if err := pool.AddLocal(pricedTransaction(nonce, 100000, big.NewInt(1), local)); err != nil {
+			t.Fatalf("failed to add local transaction: %v", err)
+		}
+	}
+
+	if have := pool.JournalStatus().Entries; have != 2 {
+		t.Fatalf("journal entries mismatch: have %d, want %d", have, 2)
+	}
+
+	// Journaling disabled, no status
+	nojournal, _ := setupPool()
+	defer nojournal.Stop()
+
+	if status := nojournal.JournalStatus(); status != nil {
+		t.Fatalf("journal status mismatch: have %+v, want nil", status)
+	}
+}
+
 func TestJournaling(t *testing.T) {
 	t.Parallel()
 	testJournaling(t, false)
@@ -667,6 +667,8 @@ type TransactionsByPriceAndNonce struct {
 	heads   TxByPriceAndTime                // Next transaction for each unique account (price heap)
 	signer  Signer                          // Signer for the set of transactions
 	baseFee *uint256.Int                    // Current base fee
+
+	firstSeen bool // Whether heads are ordered by first seen time only, ignoring their price
 }
 
 // NewTransactionsByPriceAndNonce creates a transaction set that can retrieve
@@ -705,16 +707,42 @@ func NewTransactionsByPriceAndNonce(signer Signer, txs map[common.Address]Transa
 }*/
 
 func NewTransactionsByPriceAndNonce(signer Signer, txs map[common.Address]Transactions, baseFee *uint256.Int) *TransactionsByPriceAndNonce {
-	// Initialize a price and received time based heap with the head transactions
-	heads := make(TxByPriceAndTime, 0, len(txs))
+	return newTransactionsByNonce(signer, txs, baseFee, false)
+}
 
+// NewTransactionsByNonceAndTime creates a transaction set that retrieves the
+// transactions of each account in nonce order, interleaving accounts by the time
+// their next transaction was first seen rather than by price. It is meant for
+// reproducible block building, at the expense of the collected fees.
+//
+// The first seen time is local to the node, i.e. the time the transaction was
+// received or created, so the order is only reproducible on the same node. Nodes
+// receiving the same transactions in a different order will order them
+// differently too.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// if after providing it to the constructor.
+func NewTransactionsByNonceAndTime(signer Signer, txs map[common.Address]Transactions, baseFee *uint256.Int) *TransactionsByPriceAndNonce {
+	return newTransactionsByNonce(signer, txs, baseFee, true)
+}
+
+func newTransactionsByNonce(signer Signer, txs map[common.Address]Transactions, baseFee *uint256.Int, firstSeen bool) *TransactionsByPriceAndNonce {
+	set := &TransactionsByPriceAndNonce{
+		txs:       txs,
+		heads:     make(TxByPriceAndTime, 0, len(txs)),
+		signer:    signer,
+		baseFee:   baseFee,
+		firstSeen: firstSeen,
+	}
+
+	// Initialize a price and received time based heap with the head transactions
 	for from, accTxs := range txs {
 		if len(accTxs) == 0 {
 			continue
 		}
 
 		acc, _ := Sender(signer, accTxs[0])
-		wrapped, err := NewTxWithMinerFee(accTxs[0], baseFee)
+		wrapped, err := set.wrap(accTxs[0])
 
 		// Remove transaction if sender doesn't match from, or if wrapping fails.
 		if acc != from || err != nil {
@@ -722,19 +750,29 @@ func NewTransactionsByPriceAndNonce(signer Signer, txs map[common.Address]Transa
 			continue
 		}
 
-		heads = append(heads, wrapped)
+		set.heads = append(set.heads, wrapped)
 		txs[from] = accTxs[1:]
 	}
 
-	heap.Init(&heads)
+	heap.Init(&set.heads)
 
-	// Assemble and return the transaction set
-	return &TransactionsByPriceAndNonce{
-		txs:     txs,
-		heads:   heads,
-		signer:  signer,
-		baseFee: baseFee,
+	return set
+}
+
+// wrap computes the miner fee the given transaction is ranked with. Sets
+// ordered by first seen time rank all transactions with a zero fee, so that
+// only their arrival time is taken into account.
+func (t *TransactionsByPriceAndNonce) wrap(tx *Transaction) (*TxWithMinerFee, error) {
+	wrapped, err := NewTxWithMinerFee(tx, t.baseFee)
+	if err != nil {
+		return nil, err
 	}
+
+	if t.firstSeen {
+		wrapped.minerFee = new(uint256.Int)
+	}
+
+	return wrapped, nil
 }
 
 // Peek returns the next transaction by price.
@@ -750,7 +788,7 @@ func (t *TransactionsByPriceAndNonce) Peek() *Transaction {
 func (t *TransactionsByPriceAndNonce) Shift() {
 	acc, _ := Sender(t.signer, t.heads[0].tx)
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
-		if wrapped, err := NewTxWithMinerFee(txs[0], t.baseFee); err == nil {
+		if wrapped, err := t.wrap(txs[0]); err == nil {
 			t.heads[0], t.txs[acc] = wrapped, txs[1:]
 			heap.Fix(&t.heads, 0)
 
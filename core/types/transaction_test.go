@@ -462,6 +462,54 @@ func TestTransactionTimeSort(t *testing.T) {
 	}
 }
 
+// Tests that a first seen ordered transaction set ignores prices, while still
+// honouring the nonce ordering of each account.
+func TestTransactionFirstSeenSort(t *testing.T) {
+	t.Parallel()
+
+	cheap, _ := crypto.GenerateKey()
+	pricey, _ := crypto.GenerateKey()
+
+	signer := HomesteadSigner{}
+
+	// The cheap account's transactions are seen before the expensive ones
+	groups := func() map[common.Address]Transactions {
+		groups := map[common.Address]Transactions{}
+
+		for nonce := uint64(0); nonce < 2; nonce++ {
+			for i, key := range []*ecdsa.PrivateKey{cheap, pricey} {
+				tx, _ := SignTx(NewTransaction(nonce, common.Address{}, big.NewInt(100), 100, big.NewInt(int64(1+i*10)), nil), signer, key)
+				tx.time = time.Unix(0, int64(2*nonce)+int64(i))
+
+				addr := crypto.PubkeyToAddress(key.PublicKey)
+				groups[addr] = append(groups[addr], tx)
+			}
+		}
+
+		return groups
+	}
+
+	collect := func(txset *TransactionsByPriceAndNonce) []uint64 {
+		var prices []uint64
+
+		for tx := txset.Peek(); tx != nil; tx = txset.Peek() {
+			prices = append(prices, tx.GasPrice().Uint64()*10+tx.Nonce())
+			txset.Shift()
+		}
+
+		return prices
+	}
+
+	// Prices are encoded as price*10 + nonce for compact comparison
+	if have, want := collect(NewTransactionsByPriceAndNonce(signer, groups(), nil)), []uint64{110, 111, 10, 11}; !reflect.DeepEqual(have, want) {
+		t.Fatalf("price order mismatch: have %v, want %v", have, want)
+	}
+
+	if have, want := collect(NewTransactionsByNonceAndTime(signer, groups(), nil)), []uint64{10, 110, 11, 111}; !reflect.DeepEqual(have, want) {
+		t.Fatalf("first seen order mismatch: have %v, want %v", have, want)
+	}
+}
+
 // TestTransactionCoding tests serializing/de-serializing to/from rlp and JSON.
 func TestTransactionCoding(t *testing.T) {
 	key, err := crypto.GenerateKey()
@@ -56,6 +56,9 @@ type Config struct {
 	// parallel EVM configs
 	ParallelEnable               bool
 	ParallelSpeculativeProcesses int
+	ParallelFatalOnDivergence    bool // Fail the import instead of re-executing serially if the parallel state is invalid
+	ParallelMaxWorkers           int  // Maximum number of parallel execution workers, including the speculative ones (0 = unlimited)
+	ParallelShadowVerify         bool // Wait for the serial processor as well and compare its results with the parallel ones
 }
 
 // ScopeContext contains the things that are per-call, such as stack and memory,
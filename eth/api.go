@@ -19,9 +19,11 @@ package eth
 import (
 	"compress/gzip"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"os"
 	"runtime"
@@ -30,10 +32,14 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -63,14 +69,90 @@ func (api *EthereumAPI) Coinbase() (common.Address, error) {
 
 // Hashrate returns the POW hashrate.
 func (api *EthereumAPI) Hashrate() hexutil.Uint64 {
+	if api.e.miner == nil {
+		return 0
+	}
+
 	return hexutil.Uint64(api.e.Miner().Hashrate())
 }
 
+// BorLogsEnabled returns whether state-sync logs are included in the results of
+// eth_getLogs and log subscriptions.
+func (api *EthereumAPI) BorLogsEnabled() bool {
+	return api.e.config.BorLogs
+}
+
+// MergeStatus describes the progress of the chain through the PoW to PoS
+// transition tracked by the merger.
+type MergeStatus struct {
+	TTDReached   bool `json:"ttdReached"`   // Whether the chain has left the PoW stage
+	PoSFinalized bool `json:"posFinalized"` // Whether the chain has entered the PoS stage
+}
+
+// MergeStatus returns the consensus transition status tracked by the merger.
+func (api *EthereumAPI) MergeStatus() *MergeStatus {
+	merger := api.e.Merger()
+
+	return &MergeStatus{
+		TTDReached:   merger.TDDReached(),
+		PoSFinalized: merger.PoSFinalized(),
+	}
+}
+
 // Mining returns an indication if this node is currently mining.
 func (api *EthereumAPI) Mining() bool {
 	return api.e.IsMining()
 }
 
+// ReprocessGasPriceCache re-runs the gas price oracle cache warm-up against the
+// recent blocks and returns the resulting suggested gas price. The call is rate
+// limited to protect the node.
+func (api *EthereumAPI) ReprocessGasPriceCache(ctx context.Context) (*hexutil.Big, error) {
+	price, err := api.e.ReprocessGasPriceCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*hexutil.Big)(price), nil
+}
+
+// SuggestGasPriceBor returns a gas price suggestion whose tip is never below
+// the minimum accepted by the node, so transactions using it aren't rejected by
+// the transaction pool floor.
+func (api *EthereumAPI) SuggestGasPriceBor(ctx context.Context) (*hexutil.Big, error) {
+	price, err := api.e.SuggestGasPriceBor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*hexutil.Big)(price), nil
+}
+
+// GasPriceOracleConfig holds the tunable sampling parameters of the gas price
+// oracle. Fields left out keep their current value when setting them.
+type GasPriceOracleConfig struct {
+	Blocks      *int         `json:"blocks"`
+	Percentile  *int         `json:"percentile"`
+	MaxPrice    *hexutil.Big `json:"maxPrice"`
+	IgnorePrice *hexutil.Big `json:"ignorePrice"`
+}
+
+// newGasPriceOracleConfig converts oracle parameters to their RPC form.
+func newGasPriceOracleConfig(config gasprice.Config) *GasPriceOracleConfig {
+	return &GasPriceOracleConfig{
+		Blocks:      &config.Blocks,
+		Percentile:  &config.Percentile,
+		MaxPrice:    (*hexutil.Big)(config.MaxPrice),
+		IgnorePrice: (*hexutil.Big)(config.IgnorePrice),
+	}
+}
+
+// GasPriceOracleConfig returns the sampling parameters currently used by the gas
+// price oracle. Changing them is left to the admin API.
+func (api *EthereumAPI) GasPriceOracleConfig() *GasPriceOracleConfig {
+	return newGasPriceOracleConfig(api.e.GasPriceOracleConfig())
+}
+
 // MinerAPI provides an API to control the miner.
 type MinerAPI struct {
 	e *Ethereum
@@ -81,6 +163,57 @@ func NewMinerAPI(e *Ethereum) *MinerAPI {
 	return &MinerAPI{e}
 }
 
+var (
+	// errNoPendingWork is returned if the miner has no pending block to seal.
+	errNoPendingWork = errors.New("no pending work available")
+
+	// errNotInTurnProposer is returned if the node isn't the expected proposer
+	// for the pending block.
+	errNotInTurnProposer = errors.New("not the in-turn proposer")
+)
+
+// PendingWork is a read-only snapshot of the current bor mining task.
+type PendingWork struct {
+	Header       *types.Header       `json:"header"`
+	Transactions []common.Hash       `json:"transactions"`
+	Bor          *bor.SealingContext `json:"bor"`
+}
+
+// GetPendingWork returns the pending block header being mined along with the
+// included transaction hashes and the bor sealing context. It fails if the node
+// doesn't run bor or isn't the in-turn proposer for the pending block.
+func (api *MinerAPI) GetPendingWork(ctx context.Context) (*PendingWork, error) {
+	engine, ok := api.e.borEngine()
+	if !ok {
+		return nil, ErrNotBorConsensus
+	}
+
+	block := api.e.miner.PendingBlock()
+	if block == nil {
+		return nil, errNoPendingWork
+	}
+
+	sealingContext, err := engine.GetSealingContext(ctx, api.e.blockchain, block.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	if !sealingContext.InTurn {
+		return nil, fmt.Errorf("%w: succession number %d, proposer %v", errNotInTurnProposer, sealingContext.SuccessionNumber, sealingContext.Proposer)
+	}
+
+	txs := make([]common.Hash, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		txs = append(txs, tx.Hash())
+	}
+
+	return &PendingWork{
+		Header:       block.Header(),
+		Transactions: txs,
+		Bor:          sealingContext,
+	}, nil
+}
+
 // Start starts the miner with the given number of threads. If threads is nil,
 // the number of workers started is equal to the number of logical CPUs that are
 // usable by this process. If mining is already running, this method adjust the
@@ -100,6 +233,26 @@ func (api *MinerAPI) Stop() {
 	api.e.StopMining()
 }
 
+// Pause halts block creation without stopping the sealing engine, leaving the
+// engine threads and signer authorization intact for a fast Resume.
+func (api *MinerAPI) Pause() error {
+	return api.e.PauseMining()
+}
+
+// Resume restarts block creation halted by Pause.
+func (api *MinerAPI) Resume() error {
+	return api.e.ResumeMining()
+}
+
+// Paused reports whether block creation is currently paused.
+func (api *MinerAPI) Paused() (bool, error) {
+	if api.e.miner == nil {
+		return false, ErrObserverMode
+	}
+
+	return api.e.miner.Paused(), nil
+}
+
 // SetExtra sets the extra data string that is included when this miner mines a block.
 func (api *MinerAPI) SetExtra(extra string) (bool, error) {
 	if err := api.e.Miner().SetExtra([]byte(extra)); err != nil {
@@ -121,15 +274,21 @@ func (api *MinerAPI) SetGasPrice(gasPrice hexutil.Big) bool {
 }
 
 // SetGasLimit sets the gaslimit to target towards during mining.
-func (api *MinerAPI) SetGasLimit(gasLimit hexutil.Uint64) bool {
-	api.e.Miner().SetGasCeil(uint64(gasLimit))
-	return true
+func (api *MinerAPI) SetGasLimit(gasLimit hexutil.Uint64) (bool, error) {
+	if err := api.e.SetGasCeil(uint64(gasLimit)); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 // SetEtherbase sets the etherbase of the miner.
-func (api *MinerAPI) SetEtherbase(etherbase common.Address) bool {
-	api.e.SetEtherbase(etherbase)
-	return true
+func (api *MinerAPI) SetEtherbase(etherbase common.Address) (bool, error) {
+	if err := api.e.SetEtherbase(etherbase); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 // SetRecommitInterval updates the interval for miner sealing work recommitting.
@@ -137,6 +296,47 @@ func (api *MinerAPI) SetRecommitInterval(interval int) {
 	api.e.Miner().SetRecommitInterval(time.Duration(interval) * time.Millisecond)
 }
 
+// TxPoolLocalsAPI provides an API to inspect the accounts regarded as local by
+// the node. Changing the locals is left to the admin API, as they are exempt
+// from the pool's pricing rules.
+type TxPoolLocalsAPI struct {
+	e *Ethereum
+}
+
+// NewTxPoolLocalsAPI creates a new TxPoolLocalsAPI instance.
+func NewTxPoolLocalsAPI(e *Ethereum) *TxPoolLocalsAPI {
+	return &TxPoolLocalsAPI{e}
+}
+
+// ListLocals returns the accounts currently regarded as local.
+func (api *TxPoolLocalsAPI) ListLocals() []common.Address {
+	return api.e.Locals()
+}
+
+// errTxJournalDisabled is returned if the journal status is requested while the
+// local transactions aren't journaled.
+var errTxJournalDisabled = errors.New("transaction journal disabled")
+
+// JournalStatus returns the path, last rotation time and number of entries of
+// the local transaction journal, to diagnose local transaction persistence.
+func (api *TxPoolLocalsAPI) JournalStatus() (*txpool.JournalStatus, error) {
+	status := api.e.txPool.JournalStatus()
+	if status == nil {
+		return nil, errTxJournalDisabled
+	}
+
+	return status, nil
+}
+
+// TxPoolReconcileResult is the head block the transaction pool was reconciled
+// against, along with the resulting pool size.
+type TxPoolReconcileResult struct {
+	Number  hexutil.Uint64 `json:"number"`
+	Hash    common.Hash    `json:"hash"`
+	Pending int            `json:"pending"`
+	Queued  int            `json:"queued"`
+}
+
 // AdminAPI is the collection of Ethereum full node related APIs for node
 // administration.
 type AdminAPI struct {
@@ -190,6 +390,126 @@ func (api *AdminAPI) ExportChain(file string, first *uint64, last *uint64) (bool
 	return true, nil
 }
 
+// EffectiveConfig returns the configuration in use by the node, reflecting the
+// values sanitized at startup, with secrets redacted.
+func (api *AdminAPI) EffectiveConfig() *ethconfig.Config {
+	return api.eth.EffectiveConfig()
+}
+
+// DnsDiscoveryStatus returns the number of nodes discovered and the time of the
+// last discovery for every configured ENR tree.
+func (api *AdminAPI) DnsDiscoveryStatus() []*DNSDiscoveryStatus {
+	return api.eth.DNSDiscoveryStatus()
+}
+
+// NodeENR returns the current record of the local node, kept fresh by the ENR
+// updater, along with its decoded fork identifier and the discovery status.
+func (api *AdminAPI) NodeENR() *NodeENR {
+	return api.eth.NodeENR()
+}
+
+// DiskStatus returns the free disk space of the chain database as of the last
+// check, and whether block import and mining are paused because of it.
+func (api *AdminAPI) DiskStatus() *DiskStatus {
+	return api.eth.DiskStatus()
+}
+
+// PeerProtocols returns the eth and snap protocol versions negotiated with every
+// connected peer along with the head it advertised.
+func (api *AdminAPI) PeerProtocols() []*PeerProtocols {
+	return api.eth.PeerProtocols()
+}
+
+// SetNoPruning switches trie pruning on (false) or off (true). Pruning can't be
+// re-enabled on a running archive node, so the switch is persisted and applied
+// on the next restart, which is signalled by returning true. Disabling pruning
+// on a pruned node is not possible without a restart and returns an error.
+func (api *AdminAPI) SetNoPruning(noPruning bool) (bool, error) {
+	return api.eth.SetNoPruning(noPruning)
+}
+
+// AddTxPoolLocal marks the given account as local to the transaction pool. It
+// returns false if the account was already local.
+func (api *AdminAPI) AddTxPoolLocal(account common.Address) bool {
+	return api.eth.AddLocal(account)
+}
+
+// RemoveTxPoolLocal stops treating the given account as local to the transaction
+// pool. It returns false if the account was not local.
+func (api *AdminAPI) RemoveTxPoolLocal(account common.Address) bool {
+	return api.eth.RemoveLocal(account)
+}
+
+// ReconcileTxPoolLocals forces a reset of the transaction pool against the
+// current head block, revalidating its transactions, e.g. after the chain was
+// rewound. The call is rate limited to protect the node.
+func (api *AdminAPI) ReconcileTxPoolLocals() (*TxPoolReconcileResult, error) {
+	head, err := api.eth.ReconcileTxPoolOnDemand()
+	if err != nil {
+		return nil, err
+	}
+
+	pending, queued := api.eth.txPool.Stats()
+
+	return &TxPoolReconcileResult{
+		Number:  hexutil.Uint64(head.Number.Uint64()),
+		Hash:    head.Hash(),
+		Pending: pending,
+		Queued:  queued,
+	}, nil
+}
+
+// SetCheckpointEnforcement toggles whether the downloader validates peers
+// against the whitelisted checkpoints. It's meant as an incident response lever
+// to catch up with the canonical chain after a bad checkpoint, and should be
+// switched back on afterwards.
+func (api *AdminAPI) SetCheckpointEnforcement(enabled bool) bool {
+	api.eth.Downloader().SetCheckpointEnforcement(enabled)
+	return true
+}
+
+// CheckpointEnforcement reports whether the downloader validates peers against
+// the whitelisted checkpoints.
+func (api *AdminAPI) CheckpointEnforcement() bool {
+	return api.eth.Downloader().CheckpointEnforcement()
+}
+
+// SetSnapDiscovery pauses (false) or resumes (true) seeking new snap peers,
+// without affecting eth peering. It's meant to temporarily shed snap serving
+// load during maintenance.
+func (api *AdminAPI) SetSnapDiscovery(enabled bool) bool {
+	api.eth.SetSnapDiscovery(enabled)
+	return true
+}
+
+// SnapDiscovery reports whether new snap peers are being sought.
+func (api *AdminAPI) SnapDiscovery() bool {
+	return api.eth.SnapDiscovery()
+}
+
+// SetGasPriceOracleConfig reconfigures the sampling of the gas price oracle
+// without restarting the node, returning the previous configuration.
+func (api *AdminAPI) SetGasPriceOracleConfig(args GasPriceOracleConfig) (*GasPriceOracleConfig, error) {
+	previous, err := api.eth.SetGasPriceOracleConfig(&args)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGasPriceOracleConfig(previous), nil
+}
+
+// ExportWhitelist returns the checkpoint whitelist of the node, to be imported
+// into another one with ImportWhitelist.
+func (api *AdminAPI) ExportWhitelist() (*WhitelistExport, error) {
+	return api.eth.ExportWhitelist()
+}
+
+// ImportWhitelist whitelists the checkpoints exported by another node, sparing
+// the initial heimdall round trip. It returns the number of imported checkpoints.
+func (api *AdminAPI) ImportWhitelist(export WhitelistExport) (int, error) {
+	return api.eth.ImportWhitelist(&export)
+}
+
 func hasAllBlocks(chain *core.BlockChain, bs []*types.Block) bool {
 	for _, b := range bs {
 		if !chain.HasBlock(b.Hash(), b.NumberU64()) {
@@ -202,6 +522,10 @@ func hasAllBlocks(chain *core.BlockChain, bs []*types.Block) bool {
 
 // ImportChain imports a blockchain from a local file.
 func (api *AdminAPI) ImportChain(file string) (bool, error) {
+	if api.eth.readOnly {
+		return false, ErrReadOnlyDatabase
+	}
+
 	// Make sure the can access the file to import
 	in, err := os.Open(file)
 	if err != nil {
@@ -265,6 +589,249 @@ func NewDebugAPI(eth *Ethereum) *DebugAPI {
 	return &DebugAPI{eth: eth}
 }
 
+// TrieCacheStats reports the trie cache allocation and current usage.
+type TrieCacheStats struct {
+	CleanLimit    common.StorageSize `json:"cleanLimit"`
+	DirtyLimit    common.StorageSize `json:"dirtyLimit"`
+	SnapshotLimit common.StorageSize `json:"snapshotLimit"`
+	DirtyDisabled bool               `json:"dirtyDisabled"`
+
+	CleanUsage    common.StorageSize `json:"cleanUsage"`
+	DirtyUsage    common.StorageSize `json:"dirtyUsage"`
+	PreimageUsage common.StorageSize `json:"preimageUsage"`
+
+	WarmedEntries uint64 `json:"warmedEntries"` // Nodes cached by the post-sync state warmup
+}
+
+// TrieCacheStats returns the configured trie cache limits along with the memory
+// currently used by the caches of the blockchain's trie database.
+func (api *DebugAPI) TrieCacheStats() *TrieCacheStats {
+	var (
+		config = api.eth.config
+		triedb = api.eth.blockchain.TrieDB()
+	)
+
+	dirty, preimages := triedb.Size()
+
+	return &TrieCacheStats{
+		CleanLimit:    common.StorageSize(config.TrieCleanCache) * 1024 * 1024,
+		DirtyLimit:    common.StorageSize(config.TrieDirtyCache) * 1024 * 1024,
+		SnapshotLimit: common.StorageSize(config.SnapshotCache) * 1024 * 1024,
+		DirtyDisabled: config.NoPruning,
+		CleanUsage:    triedb.CleanSize(),
+		DirtyUsage:    dirty,
+		PreimageUsage: preimages,
+		WarmedEntries: api.eth.WarmedStateEntries(),
+	}
+}
+
+// BloomIndexStatus describes how far the bloom bits index lags behind the head.
+type BloomIndexStatus struct {
+	SectionSize     uint64 `json:"sectionSize"`
+	Sections        uint64 `json:"sections"`        // Sections indexed so far
+	HeadSections    uint64 `json:"headSections"`    // Sections completed by the current head
+	PendingSections uint64 `json:"pendingSections"` // Completed sections not yet indexed
+	IndexedHead     uint64 `json:"indexedHead"`     // Last block covered by the index
+	Head            uint64 `json:"head"`
+}
+
+// DumpDiagnostics writes a JSON report combining the sync, peer, whitelist and
+// mining state, the effective configuration and the recent checkpoint
+// rejections into a new file at the given path, for support purposes. Secrets
+// are redacted.
+func (api *DebugAPI) DumpDiagnostics(path string) (bool, error) {
+	if err := writeDiagnosticsReport(path, api.eth.DiagnosticsReport()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// BloomIndexStatus returns the progress of the bloom bits indexer relative to
+// the current head. Logs of unindexed sections are filtered much slower.
+func (api *DebugAPI) BloomIndexStatus() *BloomIndexStatus {
+	var (
+		indexer     = api.eth.bloomIndexer
+		sectionSize = indexer.SectionSize()
+		head        = api.eth.blockchain.CurrentBlock().Number.Uint64()
+	)
+
+	sections, indexedHead, _ := indexer.Sections()
+
+	status := &BloomIndexStatus{
+		SectionSize:  sectionSize,
+		Sections:     sections,
+		HeadSections: (head + 1) / sectionSize,
+		Head:         head,
+	}
+
+	if sections > 0 {
+		status.IndexedHead = indexedHead
+	}
+
+	if status.HeadSections > sections {
+		status.PendingSections = status.HeadSections - sections
+	}
+
+	return status
+}
+
+// ForceBloomIndex nudges the bloom bits indexer to process any completed
+// sections up to the current head, and returns its progress.
+func (api *DebugAPI) ForceBloomIndex() (*BloomIndexStatus, error) {
+	if api.eth.readOnly {
+		return nil, ErrReadOnlyDatabase
+	}
+
+	api.eth.bloomIndexer.Update(api.eth.blockchain.CurrentBlock().Number.Uint64())
+
+	return api.BloomIndexStatus(), nil
+}
+
+// SnapshotStatus describes the state of the state snapshot and its generation.
+type SnapshotStatus struct {
+	Enabled    bool          `json:"enabled"`
+	Root       common.Hash   `json:"root"`
+	Generating bool          `json:"generating"`
+	Marker     hexutil.Bytes `json:"marker,omitempty"`   // Last account (and slot) generated
+	Progress   float64       `json:"progress,omitempty"` // Estimated share of the accounts generated, in percent
+}
+
+// SnapshotStatus returns whether the state snapshot is available or still being
+// generated, along with the generation progress.
+func (api *DebugAPI) SnapshotStatus() (*SnapshotStatus, error) {
+	snaps := api.eth.blockchain.Snapshots()
+	if snaps == nil {
+		return &SnapshotStatus{}, nil
+	}
+
+	root, marker, err := snaps.Generation()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &SnapshotStatus{
+		Enabled:    true,
+		Root:       root,
+		Generating: marker != nil,
+	}
+
+	if status.Generating {
+		status.Marker = marker
+		status.Progress = snapshotProgress(marker)
+	}
+
+	return status, nil
+}
+
+// RegenerateSnapshot wipes the state snapshot and starts regenerating it in the
+// background from the current head state, e.g. after an unclean shutdown left
+// it incomplete. It fails if a generation is already running.
+func (api *DebugAPI) RegenerateSnapshot() (*SnapshotStatus, error) {
+	if api.eth.readOnly {
+		return nil, ErrReadOnlyDatabase
+	}
+
+	if err := api.eth.blockchain.RegenerateSnapshot(); err != nil {
+		return nil, err
+	}
+
+	return api.SnapshotStatus()
+}
+
+// TrieCacheJournal describes the journal of the trie clean cache.
+type TrieCacheJournal struct {
+	Path        string    `json:"path"`
+	Entries     uint64    `json:"entries"`
+	LastJournal time.Time `json:"lastJournal"`
+}
+
+// FlushTrieCacheJournal journals the trie clean cache to disk immediately, so a
+// restart right after, e.g. a planned one, starts with a warm cache.
+func (api *DebugAPI) FlushTrieCacheJournal() (*TrieCacheJournal, error) {
+	if api.eth.readOnly {
+		return nil, ErrReadOnlyDatabase
+	}
+
+	path, err := api.eth.blockchain.FlushTrieCacheJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, last := api.eth.blockchain.TrieDB().CleanCacheJournal()
+
+	return &TrieCacheJournal{
+		Path:        path,
+		Entries:     entries,
+		LastJournal: last,
+	}, nil
+}
+
+// StateRootVerification is the outcome of re-executing a block and comparing the
+// computed state root against the stored one.
+type StateRootVerification struct {
+	Number   hexutil.Uint64 `json:"number"`
+	Hash     common.Hash    `json:"hash"`
+	Expected common.Hash    `json:"expected"`
+	Actual   common.Hash    `json:"actual"`
+	Match    bool           `json:"match"`
+	Parallel bool           `json:"parallel"` // Whether the parallel processor produced the actual root
+}
+
+// VerifyStateRoot re-executes the given block with the processor the node is
+// configured with (parallel or serial) and compares the computed state root to
+// the one stored in the header, to catch silent state divergence.
+func (api *DebugAPI) VerifyStateRoot(number rpc.BlockNumber) (*StateRootVerification, error) {
+	var block *types.Block
+
+	switch number {
+	case rpc.PendingBlockNumber:
+		return nil, errors.New("pending block can't be verified")
+	case rpc.LatestBlockNumber:
+		block = api.eth.blockchain.GetBlockByHash(api.eth.blockchain.CurrentBlock().Hash())
+	default:
+		block = api.eth.blockchain.GetBlockByNumber(uint64(number))
+	}
+
+	if block == nil {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not executed")
+	}
+
+	root, parallel, err := api.eth.blockchain.RecomputeStateRoot(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StateRootVerification{
+		Number:   hexutil.Uint64(block.NumberU64()),
+		Hash:     block.Hash(),
+		Expected: block.Root(),
+		Actual:   root,
+		Match:    root == block.Root(),
+		Parallel: parallel,
+	}, nil
+}
+
+// snapshotProgress estimates the share of the account space covered by the
+// given generation marker, in percent, as account hashes are uniformly spread.
+func snapshotProgress(marker []byte) float64 {
+	var prefix [8]byte
+
+	copy(prefix[:], marker)
+
+	return float64(binary.BigEndian.Uint64(prefix[:])) / math.MaxUint64 * 100
+}
+
+// ShutdownHistory returns the boot times of the previous runs of the node that
+// did not shut down gracefully, as detected on the current startup.
+func (api *DebugAPI) ShutdownHistory() []time.Time {
+	return api.eth.shutdownTracker.UncleanShutdowns()
+}
+
 // DumpBlock retrieves the entire state of the database at a given block.
 func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
 	opts := &state.DumpConfig{
@@ -276,7 +843,11 @@ func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
 		// If we're dumping the pending state, we need to request
 		// both the pending block as well as the pending state from
 		// the miner and operate on those
-		_, stateDb := api.eth.miner.Pending()
+		_, stateDb := api.eth.Pending()
+		if stateDb == nil {
+			return state.Dump{}, errors.New("pending state not available")
+		}
+
 		return stateDb.RawDump(opts), nil
 	}
 
@@ -373,7 +944,7 @@ func (api *DebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, start hex
 			// If we're dumping the pending state, we need to request
 			// both the pending block as well as the pending state from
 			// the miner and operate on those
-			_, stateDb = api.eth.miner.Pending()
+			_, stateDb = api.eth.Pending()
 		} else {
 			var header *types.Header
 			if number == rpc.LatestBlockNumber {
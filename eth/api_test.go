@@ -18,7 +18,10 @@ package eth
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
 	"sort"
@@ -26,9 +29,17 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
 )
 
@@ -242,3 +253,186 @@ func TestStorageRangeAt(t *testing.T) {
 		}
 	}
 }
+
+func TestSnapshotProgress(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		marker   []byte
+		expected float64
+	}{
+		{"just started", []byte{}, 0},
+		{"halfway account", common.HexToHash("0x80").Bytes()[31:], 50},
+		{"halfway storage", append(common.HexToHash("0x8000000000000000000000000000000000000000000000000000000000000000").Bytes(), common.Hash{}.Bytes()...), 50},
+		{"done", bytes.Repeat([]byte{0xff}, 32), 100},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if have := snapshotProgress(tc.marker); math.Abs(have-tc.expected) > 1e-9 {
+				t.Fatalf("progress mismatch: have %v, want %v", have, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMergeStatus(t *testing.T) {
+	t.Parallel()
+
+	merger := consensus.NewMerger(rawdb.NewMemoryDatabase())
+	api := NewEthereumAPI(&Ethereum{merger: merger})
+
+	if have, want := *api.MergeStatus(), (MergeStatus{}); have != want {
+		t.Fatalf("initial status mismatch: have %+v, want %+v", have, want)
+	}
+
+	merger.ReachTTD()
+
+	if have, want := *api.MergeStatus(), (MergeStatus{TTDReached: true}); have != want {
+		t.Fatalf("status after TTD mismatch: have %+v, want %+v", have, want)
+	}
+
+	merger.FinalizePoS()
+
+	if have, want := *api.MergeStatus(), (MergeStatus{TTDReached: true, PoSFinalized: true}); have != want {
+		t.Fatalf("status after PoS mismatch: have %+v, want %+v", have, want)
+	}
+}
+
+func TestGetPendingWorkRequiresBor(t *testing.T) {
+	t.Parallel()
+
+	api := NewMinerAPI(&Ethereum{engine: ethash.NewFaker()})
+
+	if _, err := api.GetPendingWork(context.Background()); !errors.Is(err, ErrNotBorConsensus) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrNotBorConsensus)
+	}
+}
+
+func TestTrieCacheStats(t *testing.T) {
+	t.Parallel()
+
+	// Disable the clean cache of the chain to have a deterministic usage
+	cacheConfig := *core.DefaultCacheConfig
+	cacheConfig.TrieCleanLimit = 0
+	cacheConfig.SnapshotLimit = 0
+
+	var (
+		gspec    = &core.Genesis{Config: params.TestChainConfig}
+		_, bs, _ = core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, nil)
+		chain, _ = core.NewBlockChain(rawdb.NewMemoryDatabase(), &cacheConfig, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	)
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(bs); err != nil {
+		t.Fatalf("failed to import blocks: %v", err)
+	}
+
+	api := NewDebugAPI(&Ethereum{
+		config:     &ethconfig.Config{TrieCleanCache: 1, TrieDirtyCache: 2, SnapshotCache: 3, NoPruning: true},
+		blockchain: chain,
+	})
+
+	stats := api.TrieCacheStats()
+	if stats.CleanLimit != 1024*1024 || stats.DirtyLimit != 2*1024*1024 || stats.SnapshotLimit != 3*1024*1024 {
+		t.Fatalf("limits mismatch: have %v/%v/%v, want 1/2/3 MiB", stats.CleanLimit, stats.DirtyLimit, stats.SnapshotLimit)
+	}
+
+	if !stats.DirtyDisabled {
+		t.Fatalf("disabled dirty cache not reported")
+	}
+
+	// The imported states are still held in memory, the clean cache is off
+	if stats.DirtyUsage == 0 {
+		t.Fatalf("dirty cache usage not reported")
+	}
+
+	if stats.CleanUsage != 0 {
+		t.Fatalf("clean cache usage mismatch: have %v, want 0", stats.CleanUsage)
+	}
+}
+
+// Tests that the gas price oracle can only be retuned through the admin API,
+// while its current configuration stays readable on the eth namespace.
+func TestGasPriceOracleConfigNamespaces(t *testing.T) {
+	t.Parallel()
+
+	eth := newMiningTestBackend(t, &ethconfig.Config{})
+	defer eth.miner.Close()
+
+	eth.APIBackend = &EthAPIBackend{eth: eth}
+	eth.APIBackend.gpo = gasprice.NewOracle(eth.APIBackend, gasprice.Config{Blocks: 1, Percentile: 60, Default: big.NewInt(params.GWei)})
+
+	srv := rpc.NewServer("", 0, 0)
+	defer srv.Stop()
+
+	if err := srv.RegisterName("eth", NewEthereumAPI(eth)); err != nil {
+		t.Fatalf("failed to register eth API: %v", err)
+	}
+
+	if err := srv.RegisterName("admin", NewAdminAPI(eth)); err != nil {
+		t.Fatalf("failed to register admin API: %v", err)
+	}
+
+	client := rpc.DialInProc(srv)
+	defer client.Close()
+
+	var config *GasPriceOracleConfig
+
+	err := client.Call(&config, "eth_setGasPriceOracleConfig", map[string]interface{}{"blocks": 5})
+
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != -32601 {
+		t.Fatalf("eth setter error mismatch: have %v, want method not found", err)
+	}
+
+	if err := client.Call(&config, "admin_setGasPriceOracleConfig", map[string]interface{}{"blocks": 5}); err != nil {
+		t.Fatalf("failed to set the oracle config: %v", err)
+	}
+
+	if *config.Blocks != 1 {
+		t.Fatalf("previous blocks mismatch: have %d, want 1", *config.Blocks)
+	}
+
+	if err := client.Call(&config, "eth_gasPriceOracleConfig"); err != nil {
+		t.Fatalf("failed to get the oracle config: %v", err)
+	}
+
+	if *config.Blocks != 5 || *config.Percentile != 60 {
+		t.Fatalf("oracle config mismatch: have %d/%d, want 5/60", *config.Blocks, *config.Percentile)
+	}
+}
+
+// Tests that dumping the pending state fails gracefully if neither the miner nor
+// the chain head can provide it.
+func TestDumpPendingBlockMissingState(t *testing.T) {
+	t.Parallel()
+
+	// Disable the caches, so that deleting the state root makes it unavailable
+	cacheConfig := *core.DefaultCacheConfig
+	cacheConfig.TrieCleanLimit = 0
+	cacheConfig.SnapshotLimit = 0
+
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		gspec    = &core.Genesis{Config: params.TestChainConfig, Alloc: core.GenesisAlloc{common.HexToAddress("0x1"): {Balance: big.NewInt(1)}}}
+		chain, _ = core.NewBlockChain(db, &cacheConfig, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	)
+	defer chain.Stop()
+
+	api := NewDebugAPI(&Ethereum{blockchain: chain})
+
+	if _, err := api.DumpBlock(rpc.PendingBlockNumber); err != nil {
+		t.Fatalf("failed to dump the pending state: %v", err)
+	}
+
+	rawdb.DeleteLegacyTrieNode(db, chain.CurrentBlock().Root)
+
+	if _, err := api.DumpBlock(rpc.PendingBlockNumber); err == nil {
+		t.Fatalf("dumped the pending state without it being available")
+	}
+}
@@ -22,7 +22,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/url"
 	"runtime"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -33,10 +35,12 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -53,6 +57,7 @@ import (
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/shutdowncheck"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
@@ -68,6 +73,58 @@ import (
 // Deprecated: use ethconfig.Config instead.
 type Config = ethconfig.Config
 
+var (
+	// ErrLightSyncUnsupported is returned by New if light sync mode is requested,
+	// which is served by les.LightEthereum instead.
+	ErrLightSyncUnsupported = errors.New("can't run ethereum.Ethereum in light sync mode, use les.LightEthereum")
+
+	// ErrInvalidSyncMode is returned by New if the configured sync mode is unknown.
+	ErrInvalidSyncMode = errors.New("invalid sync mode")
+
+	// ErrObserverMode is returned when trying to mine on a node running in
+	// read-only observer mode.
+	ErrObserverMode = errors.New("mining is disabled in observer mode")
+
+	// ErrSnapSyncWithoutSnapshots is returned by New if snap sync is requested
+	// without a snapshot cache and SnapSyncStrict is set.
+	ErrSnapSyncWithoutSnapshots = errors.New("snap sync requires a snapshot cache")
+
+	// ErrMissingGenesis is returned by New if a genesis is required, but none was
+	// configured and the database doesn't contain an existing chain to resume.
+	ErrMissingGenesis = errors.New("no genesis provided and no existing chain in the database")
+
+	// ErrNotSynced is returned by StartMining if the node isn't synced and the
+	// miner is configured to refuse mining on a stale head.
+	ErrNotSynced = errors.New("refusing to mine while the node isn't synced")
+
+	// ErrReadOnlyDatabase is returned by the operations writing to the database if
+	// it was opened read-only due to a newer version.
+	ErrReadOnlyDatabase = errors.New("database opened read-only")
+
+	// ErrInvalidSnapshotShare is returned by New if the share of the dirty trie
+	// cache moved to the snapshot cache of an archive node isn't a percentage.
+	ErrInvalidSnapshotShare = errors.New("archive snapshot cache share must be between 0 and 100")
+
+	// ErrTrieCleanCacheTooLarge is returned by New if rolling the dirty trie cache
+	// of an archive node into the clean one exceeds TrieCleanCacheMax.
+	ErrTrieCleanCacheTooLarge = errors.New("trie clean cache exceeds configured maximum")
+
+	// ErrInvalidGasCeil is returned by SetGasCeil if the requested gas limit is
+	// outside the bounds allowed by the protocol.
+	ErrInvalidGasCeil = errors.New("gas limit out of protocol bounds")
+)
+
+// DatabaseVersionError is returned by New if the database was written by a newer
+// version than this build supports and isn't allowed to be opened read-only.
+type DatabaseVersionError struct {
+	Version   uint64 // Version of the database on disk
+	Supported uint64 // Highest database version supported by this build
+}
+
+func (e *DatabaseVersionError) Error() string {
+	return fmt.Sprintf("database version is v%d, Geth %s only supports v%d", e.Version, params.VersionWithMeta, e.Supported)
+}
+
 // Ethereum implements the Ethereum full node service.
 type Ethereum struct {
 	config *ethconfig.Config
@@ -78,13 +135,17 @@ type Ethereum struct {
 	handler            *handler
 	ethDialCandidates  enode.Iterator
 	snapDialCandidates enode.Iterator
+	snapDiscovery      *gatedIterator // Gate pausing the snap dial candidates
+	dnsTrees           []*dnsIterator // Per ENR tree views of the dial candidates
 	merger             *consensus.Merger
 
 	// DB interfaces
-	chainDb ethdb.Database // Block chain database
+	chainDb  ethdb.Database // Block chain database
+	readOnly bool           // Whether the database was opened read-only due to a newer version
 
 	eventMux       *event.TypeMux
 	engine         consensus.Engine
+	engineClose    sync.Once // Ensures the consensus engine is only closed once
 	accountManager *accounts.Manager
 	authorized     bool // If consensus engine is authorized with keystore
 
@@ -97,12 +158,41 @@ type Ethereum struct {
 	miner     *miner.Miner
 	gasPrice  *big.Int
 	etherbase common.Address
+	locals    []common.Address // Accounts regarded as local miners, seeded from `txpool.locals`
+
+	zeroEtherbaseWarning sync.Once // Ensures the disabled reorg preservation warning is only logged once
 
 	networkID     uint64
 	netRPCService *ethapi.NetAPI
 
 	p2pServer *p2p.Server
 
+	whitelistHeimdall  bor.IHeimdallClient // Heimdall client dedicated to checkpoint whitelisting, if failover endpoints are configured
+	checkpointVerifier *checkpointVerifier // Verifier checking heimdall checkpoints before whitelisting (protected by lock), defaults to a root hash comparison if nil
+	lastWhitelist      time.Time           // Time of the last successful checkpoint whitelisting
+	lastWhitelistErr   error               // Error of the last checkpoint whitelisting attempt, if any
+	whitelistGraceEnd  time.Time           // End of the startup grace period during which checkpoints aren't enforced
+	whitelistStarted   time.Time           // Time the checkpoint whitelist service started, the staleness reference until a first success
+	whitelistLogger    log.Logger          // Base logger of the checkpoint whitelist service, the root logger if nil
+
+	whitelistReady     chan struct{} // Closed once the first checkpoint got whitelisted, or whitelisting is unavailable
+	whitelistReadyOnce sync.Once
+
+	lastGasPriceReprocess time.Time // Time the gas price oracle cache was last reprocessed on demand
+	lastTxPoolReconcile   time.Time // Time the transaction pool was last reconciled on demand
+
+	lastVerification checkpointVerification // Outcome of the last checkpoint verification run
+
+	whitelistFeed event.Feed // Feed of CheckpointWhitelistEvent, sent on every whitelist update
+
+	warmedStateEntries atomic.Uint64 // Number of trie nodes cached by the post-sync state warmup
+
+	chaindataPath string     // Path of the chain database, watched by the disk guard
+	diskStatus    DiskStatus // Outcome of the last free disk space check
+
+	miningHolds    miningHold // Reasons block creation is currently paused for
+	miningHoldLock sync.Mutex // Serializes the miner pauses and resumes of the holds, apart from the main lock
+
 	lock sync.RWMutex // Protects the variadic fields (e.g. gas price and etherbase)
 
 	closeCh chan struct{} // Channel to signal the background processes to exit
@@ -115,11 +205,15 @@ type Ethereum struct {
 func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	// Ensure configuration values are compatible and sane
 	if config.SyncMode == downloader.LightSync {
-		return nil, errors.New("can't run ethereum.Ethereum in light sync mode, use les.LightEthereum")
+		return nil, ErrLightSyncUnsupported
 	}
 
 	if !config.SyncMode.IsValid() {
-		return nil, fmt.Errorf("invalid sync mode %d", config.SyncMode)
+		return nil, fmt.Errorf("%w %d", ErrInvalidSyncMode, config.SyncMode)
+	}
+
+	if err := sanitizeSnapSync(config); err != nil {
+		return nil, err
 	}
 
 	if config.Miner.GasPrice == nil || config.Miner.GasPrice.Cmp(common.Big0) <= 0 {
@@ -127,27 +221,59 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		config.Miner.GasPrice = new(big.Int).Set(ethconfig.Defaults.Miner.GasPrice)
 	}
 
-	if config.NoPruning && config.TrieDirtyCache > 0 {
-		if config.SnapshotCache > 0 {
-			config.TrieCleanCache += config.TrieDirtyCache * 3 / 5
-			config.SnapshotCache += config.TrieDirtyCache * 2 / 5
-		} else {
-			config.TrieCleanCache += config.TrieDirtyCache
+	// Assemble the Ethereum object
+	chainDb, err := stack.OpenDatabaseWithFreezer("chaindata", config.DatabaseCache, config.DatabaseHandles, config.DatabaseFreezer, "ethereum/db/chaindata/", false)
+	if err != nil {
+		return nil, err
+	}
+
+	// A database written by a newer version may still be inspected or exported,
+	// but it must not be written to. Reopen it read-only and disable everything
+	// that would modify it.
+	readOnly := false
+
+	if config.DatabaseReadOnlyIfNewer && !config.SkipBcVersionCheck {
+		if bcVersion := rawdb.ReadDatabaseVersion(chainDb); bcVersion != nil && *bcVersion > core.BlockChainVersion {
+			log.Warn("Database version is newer than supported, opening read-only", "version", *bcVersion, "supported", core.BlockChainVersion)
+
+			chainDb.Close()
+
+			chainDb, err = stack.OpenDatabaseWithFreezer("chaindata", config.DatabaseCache, config.DatabaseHandles, config.DatabaseFreezer, "ethereum/db/chaindata/", true)
+			if err != nil {
+				return nil, err
+			}
+
+			readOnly = true
+
+			config.Observer = true
+			config.NoPruning = true
+			config.SnapshotCache = 0
 		}
+	}
 
-		config.TrieDirtyCache = 0
+	// Without a genesis, only an already initialised chain can be resumed if the
+	// caller opted out of implicitly writing the Ethereum main-net genesis block.
+	if config.RequireGenesis && config.Genesis == nil && rawdb.ReadCanonicalHash(chainDb, 0) == (common.Hash{}) {
+		return nil, ErrMissingGenesis
 	}
 
-	log.Info("Allocated trie memory caches", "clean", common.StorageSize(config.TrieCleanCache)*1024*1024, "dirty", common.StorageSize(config.TrieDirtyCache)*1024*1024)
+	// Honour a pruning switch requested through admin_setNoPruning on the
+	// previous run, since an archive node can't start pruning at runtime.
+	if config.NoPruning && !readOnly && rawdb.ReadPruningScheduled(chainDb) {
+		log.Warn("Enabling trie pruning as scheduled by admin_setNoPruning")
 
-	// Assemble the Ethereum object
-	chainDb, err := stack.OpenDatabaseWithFreezer("chaindata", config.DatabaseCache, config.DatabaseHandles, config.DatabaseFreezer, "ethereum/db/chaindata/", false)
-	if err != nil {
+		config.NoPruning = false
+	}
+
+	if err := redistributeDirtyCache(config); err != nil {
 		return nil, err
 	}
+	sanitizeTriesInMemory(config)
 
-	if err := pruner.RecoverPruning(stack.ResolvePath(""), chainDb, stack.ResolvePath(config.TrieCleanCacheJournal)); err != nil {
-		log.Error("Failed to recover state", "error", err)
+	log.Info("Allocated trie memory caches", "clean", common.StorageSize(config.TrieCleanCache)*1024*1024, "dirty", common.StorageSize(config.TrieDirtyCache)*1024*1024, "snapshot", common.StorageSize(config.SnapshotCache)*1024*1024)
+
+	if err := recoverPruning(pruner.RecoverPruning, stack.ResolvePath(""), chainDb, stack.ResolvePath(config.TrieCleanCacheJournal), config.FailOnPruningRecoveryError); err != nil {
+		return nil, err
 	}
 	// Transfer mining-related config to the ethash config.
 	ethashConfig := config.Ethash
@@ -170,20 +296,30 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		networkID:         config.NetworkId,
 		gasPrice:          config.Miner.GasPrice,
 		etherbase:         config.Miner.Etherbase,
+		locals:            append([]common.Address{}, config.TxPool.Locals...),
 		bloomRequests:     make(chan chan *bloombits.Retrieval),
 		bloomIndexer:      core.NewBloomIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms),
 		p2pServer:         stack.Server(),
 		closeCh:           make(chan struct{}),
+		chaindataPath:     stack.ResolvePath("chaindata"),
+		whitelistReady:    make(chan struct{}),
 		shutdownTracker:   shutdowncheck.NewShutdownTracker(chainDb),
+		readOnly:          readOnly,
 	}
 
 	ethereum.APIBackend = &EthAPIBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, ethereum, nil}
+	// Unprotected transactions allowed over RPC must make it into the pool, which
+	// adds them as local ones. Acceptance from the network is configured apart.
 	if ethereum.APIBackend.allowUnprotectedTxs {
 		log.Debug(" ###########", "Unprotected transactions allowed")
 
 		config.TxPool.AllowUnprotectedTxs = true
 	}
 
+	if config.TxPool.AllowUnprotectedRemoteTxs {
+		log.Info("Unprotected transactions allowed from the network")
+	}
+
 	gpoParams := config.GPO
 	if gpoParams.Default == nil {
 		gpoParams.Default = config.Miner.GasPrice
@@ -195,7 +331,17 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		overrides.OverrideShanghai = config.OverrideShanghai
 	}
 
-	chainConfig, _, genesisErr := core.SetupGenesisBlockWithOverride(chainDb, trie.NewDatabase(chainDb), config.Genesis, &overrides)
+	var (
+		chainConfig *params.ChainConfig
+		genesisErr  error
+	)
+
+	if readOnly {
+		chainConfig, _, genesisErr = core.LoadChainConfig(chainDb, config.Genesis, &overrides)
+	} else {
+		chainConfig, _, genesisErr = core.SetupGenesisBlockWithOverride(chainDb, trie.NewDatabase(chainDb), config.Genesis, &overrides)
+	}
+
 	if _, isCompat := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !isCompat {
 		return nil, genesisErr
 	}
@@ -203,6 +349,18 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	blockChainAPI := ethapi.NewBlockChainAPI(ethereum.APIBackend)
 	engine := ethconfig.CreateConsensusEngine(stack, chainConfig, config, &ethashConfig, cliqueConfig, config.Miner.Notify, config.Miner.Noverify, chainDb, blockChainAPI)
 	ethereum.engine = engine
+
+	if config.VerifyChainConfigWithHeimdall {
+		if borEngine, ok := engine.(*bor.Bor); ok && borEngine.HeimdallClient != nil {
+			if err := verifyChainConfigWithHeimdall(context.Background(), chainConfig, borEngine.HeimdallClient); err != nil {
+				if config.VerifyChainConfigStrict {
+					return nil, err
+				}
+
+				log.Warn("Failed to verify chain config with heimdall", "err", err)
+			}
+		}
+	}
 	// END: Bor changes
 
 	bcVersion := rawdb.ReadDatabaseVersion(chainDb)
@@ -214,9 +372,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 
 	log.Info("Initialising Ethereum protocol", "network", config.NetworkId, "dbversion", dbVer)
 
-	if !config.SkipBcVersionCheck {
+	if !config.SkipBcVersionCheck && !readOnly {
 		if bcVersion != nil && *bcVersion > core.BlockChainVersion {
-			return nil, fmt.Errorf("database version is v%d, Geth %s only supports v%d", *bcVersion, params.VersionWithMeta, core.BlockChainVersion)
+			return nil, &DatabaseVersionError{Version: *bcVersion, Supported: core.BlockChainVersion}
 		} else if bcVersion == nil || *bcVersion < core.BlockChainVersion {
 			if bcVersion != nil { // only print warning on upgrade, not on init
 				log.Warn("Upgrade blockchain database version", "from", dbVer, "to", core.BlockChainVersion)
@@ -231,6 +389,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			EnablePreimageRecording:      config.EnablePreimageRecording,
 			ParallelEnable:               config.ParallelEVM.Enable,
 			ParallelSpeculativeProcesses: config.ParallelEVM.SpeculativeProcesses,
+			ParallelFatalOnDivergence:    config.ParallelEVM.FatalOnDivergence,
+			ParallelMaxWorkers:           config.ParallelEVM.MaxWorkers,
+			ParallelShadowVerify:         config.ParallelEVM.ShadowVerify,
 		}
 		cacheConfig = &core.CacheConfig{
 			TrieCleanLimit:      config.TrieCleanCache,
@@ -243,6 +404,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			SnapshotLimit:       config.SnapshotCache,
 			Preimages:           config.Preimages,
 			TriesInMemory:       config.TriesInMemory,
+			MaxReorgDepth:       config.MaxReorgDepth,
+			ExecutionMetrics:    config.BlockExecutionMetrics,
+			ReadOnly:            readOnly,
 		}
 	)
 
@@ -262,19 +426,30 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, err
 	}
 
-	_ = ethereum.engine.VerifyHeader(ethereum.blockchain, ethereum.blockchain.CurrentHeader(), true) // TODO think on it
+	// Verifying the head may store consensus snapshots, and the bloom indexer
+	// persists its sections, neither of which a read-only database allows
+	if !readOnly {
+		_ = ethereum.engine.VerifyHeader(ethereum.blockchain, ethereum.blockchain.CurrentHeader(), true) // TODO think on it
+	}
 
 	// BOR changes
 	ethereum.APIBackend.gpo.ProcessCache()
 	// BOR changes
 
-	ethereum.bloomIndexer.Start(ethereum.blockchain)
+	if !readOnly {
+		ethereum.bloomIndexer.Start(ethereum.blockchain)
+	}
 
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
 
-	ethereum.txPool = txpool.NewTxPool(config.TxPool, ethereum.blockchain.Config(), ethereum.blockchain)
+	var txPoolOptions []func(pool *txpool.TxPool)
+	if config.TxFilter != nil {
+		txPoolOptions = append(txPoolOptions, txpool.WithTxFilter(config.TxFilter))
+	}
+
+	ethereum.txPool = txpool.NewTxPool(config.TxPool, ethereum.blockchain.Config(), ethereum.blockchain, txPoolOptions...)
 
 	// Permit the downloader to use the trie cache allowance during fast sync
 	cacheLimit := cacheConfig.TrieCleanLimit + cacheConfig.TrieDirtyLimit + cacheConfig.SnapshotLimit
@@ -298,36 +473,73 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		EthAPI:         blockChainAPI,
 		checker:        checker,
 		txArrivalWait:  ethereum.p2pServer.TxArrivalWait,
+
+		CheckpointRejections: config.WhitelistRejectionsLimit,
 	}); err != nil {
 		return nil, err
 	}
 
-	ethereum.miner = miner.New(ethereum, &config.Miner, ethereum.blockchain.Config(), ethereum.EventMux(), ethereum.engine, ethereum.isLocalBlock)
-	_ = ethereum.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
+	// Enforce the checkpoint whitelisted in the previous run until heimdall
+	// gets reached, if requested
+	if config.PersistWhitelist {
+		ethereum.restoreWhitelistedCheckpoint()
+	}
+
+	// Observer nodes only sync and serve reads, so they don't get a miner.
+	if config.Observer {
+		log.Info("Running in observer mode, block production disabled")
+	} else {
+		ethereum.miner = miner.New(ethereum, &config.Miner, ethereum.blockchain.Config(), ethereum.EventMux(), ethereum.engine, ethereum.isLocalBlock)
+		_ = ethereum.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
+	}
+
+	ethereum.resolveAutoEtherbase()
 
 	// Setup DNS discovery iterators.
 	dnsclient := dnsdisc.NewClient(dnsdisc.Config{})
 
-	ethereum.ethDialCandidates, err = dnsclient.NewIterator(ethereum.config.EthDiscoveryURLs...)
+	ethDialCandidates, ethTrees, err := newDNSDialCandidates(dnsclient, "eth", ethereum.config.EthDiscoveryURLs)
 	if err != nil {
 		return nil, err
 	}
 
-	ethereum.snapDialCandidates, err = dnsclient.NewIterator(ethereum.config.SnapDiscoveryURLs...)
+	snapDialCandidates, snapTrees, err := newDNSDialCandidates(dnsclient, "snap", ethereum.config.SnapDiscoveryURLs)
 	if err != nil {
 		return nil, err
 	}
 
+	ethereum.ethDialCandidates = ethDialCandidates
+	ethereum.snapDiscovery = newGatedIterator(snapDialCandidates)
+	ethereum.snapDialCandidates = ethereum.snapDiscovery
+	ethereum.dnsTrees = append(ethTrees, snapTrees...)
+
+	// Use a dedicated failover client for checkpoint whitelisting if additional
+	// heimdall endpoints were configured.
+	if len(config.HeimdallFailoverURLs) > 0 && !config.WithoutHeimdall {
+		ethereum.whitelistHeimdall = heimdall.NewFailoverHeimdallClient(append([]string{config.HeimdallURL}, config.HeimdallFailoverURLs...)...)
+	}
+
 	// Start the RPC service
-	ethereum.netRPCService = ethapi.NewNetAPI(ethereum.p2pServer, config.NetworkId)
+	advertisedNetworkID := config.NetworkId
+	if config.AdvertisedNetworkID != 0 {
+		advertisedNetworkID = config.AdvertisedNetworkID
+	}
+
+	ethereum.netRPCService = ethapi.NewNetAPI(ethereum.p2pServer, advertisedNetworkID)
 
 	// Register the backend on the node
 	stack.RegisterAPIs(ethereum.APIs())
-	stack.RegisterProtocols(ethereum.Protocols())
+	// A read-only node can't import blocks, so don't bother syncing with peers
+	if !readOnly {
+		stack.RegisterProtocols(ethereum.Protocols())
+	}
+
 	stack.RegisterLifecycle(ethereum)
 
 	// Successful startup; push a marker and check previous unclean shutdowns.
-	ethereum.shutdownTracker.MarkStartup()
+	if !readOnly {
+		ethereum.shutdownTracker.MarkStartup()
+	}
 
 	return ethereum, nil
 }
@@ -359,40 +571,231 @@ func (s *Ethereum) APIs() []rpc.API {
 	// Append any APIs exposed explicitly by the consensus engine
 	apis = append(apis, s.engine.APIs(s.BlockChain())...)
 
-	// BOR change starts
-	filterSystem := filters.NewFilterSystem(s.APIBackend, filters.Config{})
-	// set genesis to public filter api
-	publicFilterAPI := filters.NewFilterAPI(filterSystem, false, s.config.BorLogs)
-	// avoiding constructor changed by introducing new method to set genesis
-	publicFilterAPI.SetChainConfig(s.blockchain.Config())
-	// BOR change ends
-
-	// Append all the local APIs and return
-	return append(apis, []rpc.API{
+	// Append all the local APIs
+	apis = append(apis, []rpc.API{
 		{
 			Namespace: "eth",
 			Service:   NewEthereumAPI(s),
-		}, {
-			Namespace: "miner",
-			Service:   NewMinerAPI(s),
-		}, {
-			Namespace: "eth",
-			Service:   publicFilterAPI, // BOR related change
 		}, {
 			Namespace: "admin",
 			Service:   NewAdminAPI(s),
 		}, {
 			Namespace: "debug",
 			Service:   NewDebugAPI(s),
+		}, {
+			Namespace: "txpool",
+			Service:   NewTxPoolLocalsAPI(s),
+		}, {
+			Namespace: "bor",
+			Service:   NewBorAPI(s),
 		}, {
 			Namespace: "net",
 			Service:   s.netRPCService,
 		},
 	}...)
+
+	if s.miner != nil {
+		apis = append(apis, rpc.API{
+			Namespace: "miner",
+			Service:   NewMinerAPI(s),
+		})
+	}
+
+	// BOR change starts
+	if !s.config.DisableBorFilterAPI {
+		filterSystem := filters.NewFilterSystem(s.APIBackend, filters.Config{
+			UnindexedLimit: s.config.FilterUnindexedLimit,
+		})
+		// set genesis to public filter api
+		publicFilterAPI := filters.NewFilterAPI(filterSystem, false, s.config.BorLogs)
+		// avoiding constructor changed by introducing new method to set genesis
+		publicFilterAPI.SetChainConfig(s.blockchain.Config())
+
+		apis = append(apis, rpc.API{
+			Namespace: "eth",
+			Service:   publicFilterAPI,
+		})
+	} else {
+		log.Info("Bor filter API disabled")
+	}
+	// BOR change ends
+
+	return filterAPINamespaces(apis, s.config.RPCNamespaces)
+}
+
+// filterAPINamespaces drops the APIs whose namespace isn't enabled. A nil or
+// empty list of namespaces enables all of them.
+func filterAPINamespaces(apis []rpc.API, namespaces []string) []rpc.API {
+	if len(namespaces) == 0 {
+		return apis
+	}
+
+	enabled := make(map[string]struct{}, len(namespaces))
+	for _, namespace := range namespaces {
+		enabled[namespace] = struct{}{}
+	}
+
+	var (
+		filtered   = make([]rpc.API, 0, len(apis))
+		suppressed = make(map[string]struct{})
+	)
+
+	for _, api := range apis {
+		if _, ok := enabled[api.Namespace]; ok {
+			filtered = append(filtered, api)
+		} else {
+			suppressed[api.Namespace] = struct{}{}
+		}
+	}
+
+	if len(suppressed) > 0 {
+		names := make([]string, 0, len(suppressed))
+		for namespace := range suppressed {
+			names = append(names, namespace)
+		}
+
+		sort.Strings(names)
+		log.Info("Suppressed backend APIs", "namespaces", names)
+	}
+
+	return filtered
+}
+
+// EffectiveConfig returns a copy of the configuration in use, after sanitization
+// in New, with the genesis omitted and possible secrets redacted.
+func (s *Ethereum) EffectiveConfig() *ethconfig.Config {
+	config := *s.config
+
+	config.Genesis = nil
+	config.Ethash.Log = nil
+	config.HeimdallURL = redactURL(config.HeimdallURL)
+	config.HeimdallgRPCAddress = redactURL(config.HeimdallgRPCAddress)
+	config.RequiredBlocksURL = redactURL(config.RequiredBlocksURL)
+	config.RemoteSigner = redactURL(config.RemoteSigner)
+	config.TxFilter = nil
+
+	if config.RunHeimdallArgs != "" {
+		config.RunHeimdallArgs = redacted
+	}
+
+	failover := make([]string, 0, len(config.HeimdallFailoverURLs))
+	for _, endpoint := range config.HeimdallFailoverURLs {
+		failover = append(failover, redactURL(endpoint))
+	}
+
+	config.HeimdallFailoverURLs = failover
+
+	return &config
+}
+
+// redacted replaces secrets in the effective configuration.
+const redacted = "<redacted>"
+
+// redactURL strips the credentials and redacts the query parameters of the given
+// URL, as both may carry API keys. Unparsable values are redacted entirely.
+func redactURL(raw string) string {
+	if raw == "" {
+		return raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+
+	u.User = nil
+
+	if u.RawQuery != "" {
+		u.RawQuery = "redacted"
+	}
+
+	return u.String()
+}
+
+// redistributeDirtyCache rolls the dirty trie cache of an archive node into the
+// clean trie and snapshot caches, as it's unused without pruning. An error is
+// returned if the snapshot share isn't a percentage, or if the resulting clean
+// cache exceeds TrieCleanCacheMax.
+func redistributeDirtyCache(config *ethconfig.Config) error {
+	share := config.NoPruningSnapshotShare
+	if share < 0 || share > 100 {
+		return fmt.Errorf("%w: %d", ErrInvalidSnapshotShare, share)
+	}
+
+	if share == 0 {
+		share = ethconfig.DefaultNoPruningSnapshotShare
+	}
+
+	if !config.NoPruning || config.TrieDirtyCache <= 0 {
+		return nil
+	}
+
+	clean, snapshot := config.TrieCleanCache, config.SnapshotCache
+	if snapshot > 0 {
+		moved := config.TrieDirtyCache * share / 100
+
+		snapshot += moved
+		clean += config.TrieDirtyCache - moved
+	} else {
+		clean += config.TrieDirtyCache
+	}
+
+	if limit := config.TrieCleanCacheMax; limit > 0 && clean > limit {
+		return fmt.Errorf("%w: resolved %dMB, max %dMB", ErrTrieCleanCacheTooLarge, clean, limit)
+	}
+
+	config.TrieCleanCache, config.SnapshotCache, config.TrieDirtyCache = clean, snapshot, 0
+
+	return nil
+}
+
+// sanitizeTriesInMemory applies the default number of recent block states kept
+// in memory if unset, and raises it to the minimum needed to handle reorgs.
+func sanitizeTriesInMemory(config *ethconfig.Config) {
+	if config.TriesInMemory == 0 {
+		config.TriesInMemory = ethconfig.DefaultTriesInMemory
+		return
+	}
+
+	if config.TriesInMemory < ethconfig.MinTriesInMemory {
+		log.Warn("Sanitizing too few tries in memory", "provided", config.TriesInMemory, "updated", ethconfig.MinTriesInMemory)
+
+		config.TriesInMemory = ethconfig.MinTriesInMemory
+	}
+}
+
+// sanitizeSnapSync detects snap sync being requested without a snapshot cache,
+// in which case the snap protocol isn't served and the node can't snap sync.
+// A minimal snapshot cache is enabled, or an error returned if SnapSyncStrict
+// is set.
+func sanitizeSnapSync(config *ethconfig.Config) error {
+	if config.SyncMode != downloader.SnapSync || config.SnapshotCache > 0 {
+		return nil
+	}
+
+	if config.SnapSyncStrict {
+		return fmt.Errorf("%w, set a snapshot cache or use full sync", ErrSnapSyncWithoutSnapshots)
+	}
+
+	log.Warn("Snap sync requested without a snapshot cache, enabling a minimal one", "cache", common.StorageSize(ethconfig.MinSnapSyncSnapshotCache)*1024*1024)
+
+	config.SnapshotCache = ethconfig.MinSnapSyncSnapshotCache
+
+	return nil
+}
+
+// TriesInMemory returns the effective number of recent block states kept in memory.
+func (s *Ethereum) TriesInMemory() uint64 {
+	return s.config.TriesInMemory
 }
 
 func (s *Ethereum) ResetWithGenesisBlock(gb *types.Block) {
 	s.blockchain.ResetWithGenesisBlock(gb)
+
+	// Drop the gas price samples of the previous chain and restart the reorg
+	// tracking of the fee history cache from the new genesis.
+	s.APIBackend.gpo.Reset(true)
+	s.APIBackend.gpo.ProcessCache()
 }
 
 func (s *Ethereum) PublicBlockChainAPI() *ethapi.BlockChainAPI {
@@ -411,6 +814,41 @@ func (s *Ethereum) Etherbase() (eb common.Address, err error) {
 	return common.Address{}, fmt.Errorf("etherbase must be explicitly specified")
 }
 
+// resolveAutoEtherbase picks the first local account as etherbase if none is
+// configured and the automatic selection is enabled. It's run once on startup,
+// so that reading the etherbase never changes it.
+func (s *Ethereum) resolveAutoEtherbase() {
+	if s.config == nil || !s.config.Miner.AutoEtherbase {
+		return
+	}
+
+	if _, err := s.Etherbase(); err == nil {
+		return
+	}
+
+	var locals []accounts.Account
+	if wallets := s.accountManager.Wallets(); len(wallets) > 0 {
+		locals = wallets[0].Accounts()
+	}
+
+	if len(locals) == 0 {
+		log.Warn("No local account to pick the etherbase from")
+		return
+	}
+
+	etherbase := locals[0].Address
+
+	s.lock.Lock()
+	s.etherbase = etherbase
+	s.lock.Unlock()
+
+	if s.miner != nil {
+		s.miner.SetEtherbase(etherbase)
+	}
+
+	log.Warn("Etherbase automatically configured from the first local account", "address", etherbase)
+}
+
 // isLocalBlock checks whether the specified block is mined
 // by local miner accounts.
 //
@@ -422,17 +860,21 @@ func (s *Ethereum) isLocalBlock(header *types.Header) bool {
 		log.Warn("Failed to retrieve block author", "number", header.Number.Uint64(), "hash", header.Hash(), "err", err)
 		return false
 	}
-	// Check whether the given address is etherbase.
+	// Check whether the given address is etherbase. A zero etherbase means it was
+	// never set, so it must not match blocks authored by the zero address.
 	s.lock.RLock()
-	etherbase := s.etherbase
-	s.lock.RUnlock()
+	defer s.lock.RUnlock()
 
-	if author == etherbase {
+	if s.etherbase == (common.Address{}) {
+		s.zeroEtherbaseWarning.Do(func() {
+			log.Warn("Etherbase not set, reorg preservation of locally mined blocks is disabled")
+		})
+	} else if author == s.etherbase {
 		return true
 	}
 	// Check whether the given address is specified by `txpool.local`
-	// CLI flag.
-	for _, account := range s.config.TxPool.Locals {
+	// CLI flag or added at runtime.
+	for _, account := range s.locals {
 		if account == author {
 			return true
 		}
@@ -441,6 +883,51 @@ func (s *Ethereum) isLocalBlock(header *types.Header) bool {
 	return false
 }
 
+// Locals returns the accounts currently regarded as local miner accounts.
+func (s *Ethereum) Locals() []common.Address {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return append([]common.Address{}, s.locals...)
+}
+
+// AddLocal marks the given account as local, both for reorg preservation and
+// within the transaction pool. It returns false if the account was already local.
+func (s *Ethereum) AddLocal(account common.Address) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, local := range s.locals {
+		if local == account {
+			return false
+		}
+	}
+
+	s.locals = append(s.locals, account)
+	s.txPool.AddLocalAccount(account)
+
+	return true
+}
+
+// RemoveLocal stops treating the given account as local, both for reorg
+// preservation and within the transaction pool. It returns false if the account
+// was not local.
+func (s *Ethereum) RemoveLocal(account common.Address) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i, local := range s.locals {
+		if local == account {
+			s.locals = append(s.locals[:i:i], s.locals[i+1:]...)
+			s.txPool.RemoveLocalAccount(account)
+
+			return true
+		}
+	}
+
+	return false
+}
+
 // shouldPreserve checks whether we should preserve the given block
 // during the chain reorg depending on whether the author of block
 // is a local account.
@@ -461,96 +948,366 @@ func (s *Ethereum) shouldPreserve(header *types.Header) bool {
 	// is A, F and G sign the block of round5 and reject the block of opponents
 	// and in the round6, the last available signer B is offline, the whole
 	// network is stuck.
-	if _, ok := s.engine.(*clique.Clique); ok {
-		return false
+	preserve := false
+	if _, ok := s.engine.(*clique.Clique); !ok {
+		preserve = s.isLocalBlock(header)
 	}
 
-	return s.isLocalBlock(header)
+	// Report the decision for debugging self-reorgs. Posting is a no-op when
+	// nobody is subscribed to the event.
+	if s.eventMux != nil {
+		s.eventMux.Post(PreserveDecisionEvent{Header: header, Preserved: preserve})
+	}
+
+	return preserve
 }
 
 // SetEtherbase sets the mining reward address.
-func (s *Ethereum) SetEtherbase(etherbase common.Address) {
+func (s *Ethereum) SetEtherbase(etherbase common.Address) error {
+	if s.miner == nil {
+		return ErrObserverMode
+	}
+
 	s.lock.Lock()
 	s.etherbase = etherbase
 	s.lock.Unlock()
 
 	s.miner.SetEtherbase(etherbase)
+
+	return nil
 }
 
-// StartMining starts the miner with the given number of CPU threads. If mining
-// is already running, this method adjust the number of threads allowed to use
-// and updates the minimum price required by the transaction pool.
-func (s *Ethereum) StartMining(threads int) error {
-	// Update the thread count within the consensus engine
-	type threaded interface {
-		SetThreads(threads int)
+// SetGasCeil updates the gas limit the miner targets for the blocks produced
+// from now on. Since the protocol only allows a block's gas limit to deviate
+// from its parent's by 1/GasLimitBoundDivisor, the target is approached over
+// several blocks.
+func (s *Ethereum) SetGasCeil(limit uint64) error {
+	if limit < params.MinGasLimit || limit > params.MaxGasLimit {
+		return fmt.Errorf("%w: %d not within [%d, %d]", ErrInvalidGasCeil, limit, params.MinGasLimit, params.MaxGasLimit)
 	}
 
-	if th, ok := s.engine.(threaded); ok {
-		log.Info("Updated mining threads", "threads", threads)
+	if s.miner == nil {
+		return ErrObserverMode
+	}
 
-		if threads == 0 {
-			threads = -1 // Disable the miner from within
+	s.miner.SetGasCeil(limit)
+
+	log.Info("Updated miner gas limit target", "limit", limit)
+
+	return nil
+}
+
+// recoverPruning resumes a state pruning interrupted by a previous run using the
+// given recovery. Failures are only logged, unless strict is set, in which case
+// they are returned as the state may be corrupt.
+func recoverPruning(recoverFn func(string, ethdb.Database, string) error, datadir string, db ethdb.Database, journal string, strict bool) error {
+	if err := recoverFn(datadir, db, journal); err != nil {
+		if strict {
+			return fmt.Errorf("failed to recover state: %w", err)
 		}
 
-		th.SetThreads(threads)
+		log.Error("Failed to recover state", "error", err)
 	}
-	// If the miner was not running, initialize it
-	if !s.IsMining() {
-		// Propagate the initial price point to the transaction pool
-		s.lock.RLock()
-		price := s.gasPrice
-		s.lock.RUnlock()
-		s.txPool.SetGasPrice(price)
 
-		// Configure the local mining address
-		eb, err := s.Etherbase()
+	return nil
+}
+
+// StartMining starts the miner with the given number of CPU threads. If mining
+// is already running, this method adjust the number of threads allowed to use
+// and updates the minimum price required by the transaction pool.
+func (s *Ethereum) StartMining(threads int) error {
+	if s.miner == nil {
+		return ErrObserverMode
+	}
+
+	// Check the prerequisites before touching any miner state
+	if err := s.CanStartMining(); err != nil {
+		return err
+	}
+
+	// Update the thread count within the consensus engine
+	s.SetMiningThreads(threads)
+
+	// If the miner was not running, initialize it
+	if !s.IsMining() {
+		synced := s.Synced()
+
+		// Configure the local mining address and its signer, falling back to
+		// the etherbase candidates if the configured one can't be authorized
+		eb, wallet, err := s.miningSigner()
+		if err != nil && len(s.config.Miner.EtherbaseCandidates) > 0 {
+			if eb, wallet, err = s.etherbaseCandidateSigner(err); err == nil {
+				if err := s.SetEtherbase(eb); err != nil {
+					return err
+				}
+
+				log.Warn("Switched etherbase to failover candidate", "address", eb)
+			}
+		}
+
 		if err != nil {
-			log.Error("Cannot start mining without etherbase", "err", err)
-			return fmt.Errorf("etherbase missing: %v", err)
+			return err
 		}
+
+		// Propagate the initial price point to the transaction pool
+		s.lock.RLock()
+		price := s.gasPrice
+		s.lock.RUnlock()
+		s.txPool.SetGasPrice(price)
+
 		// If personal endpoints are disabled, the server creating
 		// this Ethereum instance has already Authorized consensus.
-		if !s.authorized {
-			var cli *clique.Clique
-			if c, ok := s.engine.(*clique.Clique); ok {
-				cli = c
-			} else if cl, ok := s.engine.(*beacon.Beacon); ok {
-				if c, ok := cl.InnerEngine().(*clique.Clique); ok {
-					cli = c
-				}
-			}
+		if wallet != nil {
+			s.authorizeEngine(eb, wallet)
+		}
 
-			if cli != nil {
-				wallet, err := s.accountManager.Find(accounts.Account{Address: eb})
-				if wallet == nil || err != nil {
-					log.Error("Etherbase account unavailable locally", "err", err)
-					return fmt.Errorf("signer missing: %v", err)
-				}
+		// If mining is started, we can disable the transaction rejection mechanism
+		// introduced to speed sync times. When waiting for the sync instead, the
+		// downloader lifts it once done.
+		if synced || s.config.Miner.SyncGuard != miner.SyncGuardWait {
+			atomic.StoreUint32(&s.handler.acceptTxs, 1)
+		}
+
+		go s.startMiner()
+	}
+
+	return nil
+}
+
+// authorizeEngine injects the signer of the given wallet into the consensus
+// engine, if it seals blocks locally. It reports whether the engine was authorized.
+func (s *Ethereum) authorizeEngine(eb common.Address, wallet accounts.Wallet) bool {
+	switch engine := s.sealingEngine().(type) {
+	case *clique.Clique:
+		engine.Authorize(eb, wallet.SignData)
+	case *bor.Bor:
+		engine.Authorize(eb, wallet.SignData)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// sealingEngine returns the engine sealing blocks locally, unwrapping it from
+// the beacon engine if needed.
+func (s *Ethereum) sealingEngine() consensus.Engine {
+	if engine, ok := s.engine.(*beacon.Beacon); ok {
+		return engine.InnerEngine()
+	}
+
+	return s.engine
+}
+
+// SetMiningThreads updates the number of threads used by the consensus engine
+// to seal blocks, if it supports it. Unlike StartMining, it doesn't touch the
+// gas price or the engine authorization, so it can be used while mining.
+func (s *Ethereum) SetMiningThreads(threads int) {
+	type threaded interface {
+		SetThreads(threads int)
+	}
+
+	if th, ok := s.engine.(threaded); ok {
+		log.Info("Updated mining threads", "threads", threads)
+
+		if threads == 0 {
+			threads = -1 // Disable the miner from within
+		}
+
+		th.SetThreads(threads)
+	}
+}
+
+// miningPeerCheckInterval is the interval at which the peer count is checked
+// while waiting for the minimum number of peers before mining starts.
+var miningPeerCheckInterval = time.Second
+
+// miningSyncCheckInterval is the interval at which the sync status is checked
+// while waiting for the node to sync before mining starts.
+var miningSyncCheckInterval = time.Second
+
+// peerCounter reports the number of currently connected peers.
+type peerCounter interface {
+	peerCount() int
+}
+
+// startMiner starts the miner, optionally waiting for the configured minimum
+// number of peers first, so sealed blocks have somewhere to be propagated to.
+func (s *Ethereum) startMiner() {
+	if s.config.Miner.SyncGuard == miner.SyncGuardWait && !waitForMiningSync(s.Synced, s.closeCh) {
+		return
+	}
+
+	if minPeers := s.config.Miner.MinPeers; minPeers > 0 {
+		if !waitForMiningPeers(s.handler, minPeers, s.config.Miner.MinPeersTimeout, s.closeCh) {
+			return
+		}
+	}
+
+	s.holdMiningIfWhitelistStale()
+	s.startHeldMiner()
+}
+
+// waitForMiningPeers blocks until at least minPeers peers are connected or the
+// timeout elapses, in which case mining proceeds anyway. It returns false if
+// the node is shutting down in the meantime.
+func waitForMiningPeers(peers peerCounter, minPeers int, timeout time.Duration, quit <-chan struct{}) bool {
+	if peers.peerCount() >= minPeers {
+		return true
+	}
+
+	log.Info("Waiting for peers before mining", "have", peers.peerCount(), "want", minPeers, "timeout", timeout)
+
+	var expired <-chan time.Time
+
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		expired = timer.C
+	}
+
+	ticker := time.NewTicker(miningPeerCheckInterval)
+	defer ticker.Stop()
 
-				cli.Authorize(eb, wallet.SignData)
+	for {
+		select {
+		case <-ticker.C:
+			if have := peers.peerCount(); have >= minPeers {
+				log.Info("Minimum peer count reached, starting mining", "peers", have)
+				return true
 			}
 
-			if bor, ok := s.engine.(*bor.Bor); ok {
-				wallet, err := s.accountManager.Find(accounts.Account{Address: eb})
-				if wallet == nil || err != nil {
-					log.Error("Etherbase account unavailable locally", "err", err)
+		case <-expired:
+			log.Warn("Timed out waiting for peers, mining anyway", "have", peers.peerCount(), "want", minPeers)
+			return true
 
-					return fmt.Errorf("signer missing: %v", err)
-				}
+		case <-quit:
+			return false
+		}
+	}
+}
 
-				bor.Authorize(eb, wallet.SignData)
+// waitForMiningSync blocks until the node reports being synced. It returns false
+// if the node is shutting down in the meantime.
+func waitForMiningSync(synced func() bool, quit <-chan struct{}) bool {
+	if synced() {
+		return true
+	}
+
+	log.Info("Waiting for sync before mining")
+
+	ticker := time.NewTicker(miningSyncCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if synced() {
+				log.Info("Node synced, starting mining")
+				return true
 			}
+
+		case <-quit:
+			return false
 		}
+	}
+}
 
-		// If mining is started, we can disable the transaction rejection mechanism
-		// introduced to speed sync times.
-		atomic.StoreUint32(&s.handler.acceptTxs, 1)
+// CanStartMining checks the prerequisites of StartMining, i.e. the sync guard,
+// the etherbase and the signer wallet required by the consensus engine, without
+// starting the miner or changing its configuration.
+func (s *Ethereum) CanStartMining() error {
+	if s.config != nil && s.config.Observer {
+		return ErrObserverMode
+	}
 
-		go s.miner.Start()
+	// Refuse to seal on top of a stale head while syncing, if requested
+	if s.config != nil && s.config.Miner.SyncGuard == miner.SyncGuardRefuse && !s.IsMining() && !s.Synced() {
+		return ErrNotSynced
 	}
 
-	return nil
+	_, _, err := s.miningSigner()
+	if err != nil && s.config != nil && len(s.config.Miner.EtherbaseCandidates) > 0 {
+		_, _, err = s.etherbaseCandidateSigner(err)
+	}
+
+	return err
+}
+
+// miningSigner resolves the etherbase along with the wallet holding its key, if
+// the consensus engine still needs to be authorized to seal blocks.
+func (s *Ethereum) miningSigner() (common.Address, accounts.Wallet, error) {
+	eb, err := s.Etherbase()
+	if err != nil {
+		log.Error("Cannot start mining without etherbase", "err", err)
+		return common.Address{}, nil, fmt.Errorf("etherbase missing: %v", err)
+	}
+
+	if s.authorized {
+		return eb, nil, nil
+	}
+
+	var needsSigner bool
+
+	switch s.sealingEngine().(type) {
+	case *clique.Clique, *bor.Bor:
+		needsSigner = true
+	}
+
+	if !needsSigner {
+		return eb, nil, nil
+	}
+
+	wallet, err := s.signerWallet(eb)
+	if err != nil {
+		log.Error("Etherbase account unavailable", "err", err)
+
+		return common.Address{}, nil, err
+	}
+
+	return eb, wallet, nil
+}
+
+// signerWallet returns the wallet holding the key of the given account, from the
+// remote signer if one is configured or from the local keystores otherwise.
+func (s *Ethereum) signerWallet(account common.Address) (accounts.Wallet, error) {
+	if s.config != nil && s.config.RemoteSigner != "" {
+		return newRemoteSigner(s.config.RemoteSigner, account)
+	}
+
+	wallet, err := s.accountManager.Find(accounts.Account{Address: account})
+	if wallet == nil || err != nil {
+		return nil, fmt.Errorf("signer missing: %v", err)
+	}
+
+	return wallet, nil
+}
+
+// etherbaseCandidateSigner tries the configured etherbase candidates in order
+// and returns the first one whose wallet is available, locally or on the remote
+// signer like the etherbase, leaving it to the caller to switch the etherbase.
+// The errors of all the attempts, including the initial one, are returned if
+// none of the candidates can be used.
+func (s *Ethereum) etherbaseCandidateSigner(initial error) (common.Address, accounts.Wallet, error) {
+	errs := []error{initial}
+
+	for i, candidate := range s.config.Miner.EtherbaseCandidates {
+		log.Info("Trying etherbase candidate", "index", i, "address", candidate)
+
+		wallet, err := s.signerWallet(candidate)
+		if err != nil {
+			log.Warn("Etherbase candidate unavailable", "index", i, "address", candidate, "err", err)
+
+			errs = append(errs, fmt.Errorf("candidate %v: %w", candidate, err))
+
+			continue
+		}
+
+		return candidate, wallet, nil
+	}
+
+	return common.Address{}, nil, fmt.Errorf("no usable etherbase candidate: %w", errors.Join(errs...))
 }
 
 // StopMining terminates the miner, both at the consensus engine level as well as
@@ -565,12 +1322,96 @@ func (s *Ethereum) StopMining() {
 		th.SetThreads(-1)
 	}
 	// Stop the block creating itself
-	s.miner.Stop()
+	if s.miner != nil {
+		s.miner.Stop()
+	}
+}
+
+// PauseMining halts block creation while leaving the consensus engine threads
+// and signer authorization in place, so mining can be resumed quickly.
+func (s *Ethereum) PauseMining() error {
+	if s.miner == nil {
+		return ErrObserverMode
+	}
+
+	s.holdMining(miningHoldManual)
+
+	return nil
+}
+
+// ResumeMining restarts block creation halted by PauseMining. It's a no-op if
+// the miner wasn't started in the first place.
+func (s *Ethereum) ResumeMining() error {
+	if s.miner == nil {
+		return ErrObserverMode
+	}
+
+	s.releaseMining(miningHoldManual)
+
+	if s.miningHeld(miningHoldDiskSpace) {
+		return errDiskSpaceLow
+	}
+
+	if s.miningHeld(miningHoldWhitelist) {
+		return errWhitelistStale
+	}
+
+	return nil
 }
 
-func (s *Ethereum) IsMining() bool      { return s.miner.Mining() }
+func (s *Ethereum) IsMining() bool      { return s.miner != nil && s.miner.Mining() }
 func (s *Ethereum) Miner() *miner.Miner { return s.miner }
 
+// Pending returns the miner's current pending block along with its state. If the
+// miner has not assembled any work yet (e.g. mining is stopped), it falls back to
+// the latest sealed block and its state. Both results are nil if neither is
+// available.
+func (s *Ethereum) Pending() (*types.Block, *state.StateDB) {
+	if s.miner != nil {
+		if block, statedb := s.miner.Pending(); block != nil && statedb != nil {
+			return block, statedb
+		}
+	}
+
+	header := s.blockchain.CurrentBlock()
+	if header == nil {
+		return nil, nil
+	}
+
+	block := s.blockchain.GetBlock(header.Hash(), header.Number.Uint64())
+	if block == nil {
+		return nil, nil
+	}
+
+	statedb, err := s.blockchain.StateAt(header.Root)
+	if err != nil {
+		return block, nil
+	}
+
+	return block, statedb
+}
+
+// PendingBlock returns the miner's current pending block, falling back to the
+// latest sealed block when no pending work is available.
+//
+// Note, to access both the pending block and the pending state simultaneously,
+// please use Pending(), as the pending state can change between multiple
+// method calls.
+func (s *Ethereum) PendingBlock() *types.Block {
+	if s.miner != nil {
+		if block := s.miner.PendingBlock(); block != nil {
+			return block
+		}
+	}
+
+	header := s.blockchain.CurrentBlock()
+	if header == nil {
+		return nil
+	}
+
+	return s.blockchain.GetBlock(header.Hash(), header.Number.Uint64())
+}
+
 func (s *Ethereum) AccountManager() *accounts.Manager  { return s.accountManager }
 func (s *Ethereum) BlockChain() *core.BlockChain       { return s.blockchain }
 func (s *Ethereum) TxPool() *txpool.TxPool             { return s.txPool }
@@ -589,6 +1430,166 @@ func (s *Ethereum) SyncMode() downloader.SyncMode {
 	return mode
 }
 
+// RegisterImportHook registers hooks invoked synchronously before and after
+// each block is imported into the local chain, either of which may be nil.
+// Unlike the event mux subscriptions, the hooks run on the import path itself,
+// so each is waited for at most a short timeout and must return promptly.
+func (s *Ethereum) RegisterImportHook(pre core.PreImportHook, post core.PostImportHook) {
+	s.blockchain.AddImportHooks(pre, post)
+}
+
+// PeerProtocols returns the negotiated protocol versions and advertised heads of
+// the connected eth peers.
+func (s *Ethereum) PeerProtocols() []*PeerProtocols {
+	return s.handler.peerProtocols()
+}
+
+var (
+	// errGasPriceReprocessThrottled is returned if the gas price oracle cache
+	// reprocessing is requested too frequently.
+	errGasPriceReprocessThrottled = errors.New("gas price cache reprocessed too recently")
+
+	// gasPriceReprocessInterval is the minimum time between two on demand gas
+	// price oracle cache reprocessing runs.
+	gasPriceReprocessInterval = time.Minute
+)
+
+// ReprocessGasPriceCache drops the gas price oracle caches, re-runs the price
+// sampling against the recent blocks and returns the resulting suggested gas
+// price. It can be called at most once per gasPriceReprocessInterval.
+func (s *Ethereum) ReprocessGasPriceCache(ctx context.Context) (*big.Int, error) {
+	s.lock.Lock()
+	if since := time.Since(s.lastGasPriceReprocess); since < gasPriceReprocessInterval {
+		s.lock.Unlock()
+		return nil, fmt.Errorf("%w, retry in %v", errGasPriceReprocessThrottled, (gasPriceReprocessInterval - since).Round(time.Second))
+	}
+	s.lastGasPriceReprocess = time.Now()
+	s.lock.Unlock()
+
+	gpo := s.APIBackend.gpo
+	gpo.Reset(false)
+
+	tipcap, err := gpo.SuggestTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if head := s.blockchain.CurrentHeader(); head.BaseFee != nil {
+		tipcap.Add(tipcap, head.BaseFee)
+	}
+
+	return tipcap, nil
+}
+
+// GasPriceOracleConfig returns the sampling parameters currently used by the
+// gas price oracle.
+func (s *Ethereum) GasPriceOracleConfig() gasprice.Config {
+	return s.APIBackend.gpo.Config()
+}
+
+// SetGasPriceOracleConfig atomically updates the sampling parameters of the gas
+// price oracle set in the given config and returns the previous ones.
+func (s *Ethereum) SetGasPriceOracleConfig(update *GasPriceOracleConfig) (gasprice.Config, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	config := s.APIBackend.gpo.Config()
+
+	if update.Blocks != nil {
+		config.Blocks = *update.Blocks
+	}
+
+	if update.Percentile != nil {
+		config.Percentile = *update.Percentile
+	}
+
+	if update.MaxPrice != nil {
+		config.MaxPrice = update.MaxPrice.ToInt()
+	}
+
+	if update.IgnorePrice != nil {
+		config.IgnorePrice = update.IgnorePrice.ToInt()
+	}
+
+	return s.APIBackend.gpo.Reconfigure(config)
+}
+
+// SuggestGasPriceBor returns the gas price oracle's suggestion with the tip
+// raised to the configured minimum gas price if needed, plus the base fee of
+// the current head.
+func (s *Ethereum) SuggestGasPriceBor(ctx context.Context) (*big.Int, error) {
+	tipcap, err := s.APIBackend.gpo.SuggestTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.RLock()
+	floor := s.gasPrice
+	s.lock.RUnlock()
+
+	return borGasPrice(tipcap, floor, s.blockchain.CurrentHeader().BaseFee), nil
+}
+
+// borGasPrice combines a suggested tip with the network floor and base fee. A
+// nil floor or base fee is ignored.
+func borGasPrice(tipcap, floor, baseFee *big.Int) *big.Int {
+	price := new(big.Int).Set(tipcap)
+	if floor != nil && price.Cmp(floor) < 0 {
+		price.Set(floor)
+	}
+
+	if baseFee != nil {
+		price.Add(price, baseFee)
+	}
+
+	return price
+}
+
+var (
+	// errPruningToArchive is returned when trying to disable trie pruning on a
+	// running pruned node.
+	errPruningToArchive = errors.New("can't switch from pruned to archive mode at runtime: state pruned so far can't be recovered, restart with --gcmode=archive (ideally on a resynced node)")
+)
+
+// SetNoPruning switches trie pruning on or off. A running node can't change
+// its pruning mode, so disabling pruning on an archive node is persisted and
+// applied on the next startup, in which case true is returned. Re-disabling
+// pruning on such a node cancels the scheduled switch. Disabling pruning on a
+// pruned node is not possible and results in an error.
+func (s *Ethereum) SetNoPruning(noPruning bool) (bool, error) {
+	if s.readOnly {
+		return false, ErrReadOnlyDatabase
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.config.NoPruning {
+		if noPruning {
+			// Drop any leftover schedule, so a restart honours the configured
+			// archive mode.
+			rawdb.DeletePruningScheduled(s.chainDb)
+			return false, errPruningToArchive
+		}
+
+		return false, nil
+	}
+
+	if noPruning {
+		if rawdb.ReadPruningScheduled(s.chainDb) {
+			log.Info("Cancelled scheduled trie pruning")
+			rawdb.DeletePruningScheduled(s.chainDb)
+		}
+
+		return false, nil
+	}
+
+	log.Info("Scheduled trie pruning, restart the node to apply")
+	rawdb.WritePruningScheduled(s.chainDb)
+
+	return true, nil
+}
+
 // SetAuthorized sets the authorized bool variable
 // denoting that consensus has been authorized while creation
 func (s *Ethereum) SetAuthorized(authorized bool) {
@@ -617,7 +1618,9 @@ func (s *Ethereum) Start() error {
 	s.startBloomHandlers(params.BloomBitsBlocks)
 
 	// Regularly update shutdown marker
-	s.shutdownTracker.Start()
+	if !s.readOnly {
+		s.shutdownTracker.Start()
+	}
 
 	// Figure out a max peers count based on the server limits
 	maxPeers := s.p2pServer.MaxPeers
@@ -635,6 +1638,30 @@ func (s *Ethereum) Start() error {
 
 	go s.startCheckpointWhitelistService()
 
+	// Pause mining while checkpoint whitelisting is stale, if requested
+	s.startWhitelistMiningGuard()
+
+	go s.reconcileTxPoolOnCheckpointMismatch()
+
+	// Require the blocks served by the remote source from new peers, if requested
+	go s.startRequiredBlocksRefreshService()
+
+	// Keep the txpool gas floor in line with the network, if requested
+	s.startTxPoolFloorUpdater()
+
+	// Re-authorize the engine when the etherbase wallet reappears, if requested
+	s.startEtherbaseReauthorizer()
+
+	if s.config.WarmStateAfterSync {
+		go s.warmStateAfterSync()
+	}
+
+	// Pause block import and mining on low disk space, if requested
+	s.startDiskGuard()
+
+	// Hold back serving RPC until the first checkpoint got whitelisted, if requested
+	s.waitWhitelistBarrier()
+
 	return nil
 }
 
@@ -642,7 +1669,15 @@ var (
 	ErrNotBorConsensus             = errors.New("not bor consensus was given")
 	ErrBorConsensusWithoutHeimdall = errors.New("bor consensus without heimdall")
 
+	// errWhitelistGracePeriod is returned if the checkpoints were fetched and
+	// verified, but not whitelisted as the startup grace period isn't over yet.
+	errWhitelistGracePeriod = errors.New("checkpoint whitelist not enforced during grace period")
+
 	whitelistTimeout = 30 * time.Second
+
+	// whitelistLagGauge tracks how many blocks the local head is ahead of the
+	// last whitelisted checkpoint, i.e. the length of the unverified chain.
+	whitelistLagGauge = metrics.NewRegisteredGauge("eth/whitelist/lag", nil)
 )
 
 // StartCheckpointWhitelistService starts the goroutine to fetch checkpoints and update the
@@ -655,18 +1690,40 @@ func (s *Ethereum) startCheckpointWhitelistService() {
 	default:
 	}
 
-	// first run the checkpoint whitelist
-	firstCtx, cancel := context.WithTimeout(context.Background(), whitelistTimeout)
+	// Checkpoints are only enforced once the grace period is over. Until then,
+	// keep treating runs as the first one so the full range gets whitelisted.
+	s.lock.Lock()
+	s.whitelistStarted = time.Now()
+	s.whitelistGraceEnd = s.whitelistStarted.Add(s.config.WhitelistGracePeriod)
+	s.lock.Unlock()
+
+	// first run the checkpoint whitelist, allowing heimdall more time to answer
+	// as it may still be warming up at boot
+	firstTimeout := whitelistTimeout
+	if s.config.WhitelistFirstTimeout > 0 {
+		firstTimeout = s.config.WhitelistFirstTimeout
+	}
+
+	// Tie all heimdall requests to the node lifetime, so Stop doesn't have to
+	// wait for in-flight ones to time out
+	parent, stop := s.closeContext()
+	defer stop()
+
+	firstCtx, cancel := context.WithTimeout(parent, firstTimeout)
 	err := s.handleWhitelistCheckpoint(firstCtx, true)
+	first := s.inWhitelistGracePeriod()
 
 	cancel()
+	s.recordWhitelistResult(err)
+	s.updateWhitelistLag()
 
 	if err != nil {
 		if errors.Is(err, ErrBorConsensusWithoutHeimdall) || errors.Is(err, ErrNotBorConsensus) {
+			s.markWhitelistReady()
 			return
 		}
 
-		log.Warn("unable to whitelist checkpoint - first run", "err", err)
+		s.logWhitelistFailure("unable to whitelist checkpoint - first run", err)
 	}
 
 	ticker := time.NewTicker(100 * time.Second)
@@ -675,13 +1732,16 @@ func (s *Ethereum) startCheckpointWhitelistService() {
 	for {
 		select {
 		case <-ticker.C:
-			ctx, cancel := context.WithTimeout(context.Background(), whitelistTimeout)
-			err := s.handleWhitelistCheckpoint(ctx, false)
+			ctx, cancel := context.WithTimeout(parent, whitelistTimeout)
+			err := s.handleWhitelistCheckpoint(ctx, first)
+			first = first && s.inWhitelistGracePeriod()
 
 			cancel()
+			s.recordWhitelistResult(err)
+			s.updateWhitelistLag()
 
 			if err != nil {
-				log.Warn("unable to whitelist checkpoint", "err", err)
+				s.logWhitelistFailure("unable to whitelist checkpoint", err)
 			}
 		case <-s.closeCh:
 			return
@@ -689,6 +1749,185 @@ func (s *Ethereum) startCheckpointWhitelistService() {
 	}
 }
 
+// logWhitelistFailure logs a failed checkpoint whitelisting round. Heimdall not
+// having proposed any checkpoint yet is expected on fresh networks rather than
+// a failure, so it's only logged at info level.
+func (s *Ethereum) logWhitelistFailure(msg string, err error) {
+	// Rounds skipped during the grace period are already reported
+	if errors.Is(err, errWhitelistGracePeriod) {
+		return
+	}
+
+	if errors.Is(err, ErrNoCheckpointsYet) {
+		s.whitelistLog().Info("No checkpoint proposed by heimdall yet", "head", s.whitelistHead())
+		return
+	}
+
+	s.whitelistLog().Warn(msg, "head", s.whitelistHead(), "err", err)
+}
+
+// closeContext returns a context which is canceled as soon as the node shuts
+// down, or the returned cancel function is called.
+func (s *Ethereum) closeContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		select {
+		case <-s.closeCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// whitelistLog returns the logger of the checkpoint whitelist service, tagging
+// all records with a stable component key and the heimdall endpoint in use.
+func (s *Ethereum) whitelistLog() log.Logger {
+	logger := s.whitelistLogger
+	if logger == nil {
+		logger = log.Root()
+	}
+
+	return logger.New("component", "checkpoint-whitelist", "heimdall", s.whitelistEndpoint())
+}
+
+// whitelistEndpoint returns the redacted URL of the heimdall endpoint used for
+// checkpoint whitelisting.
+func (s *Ethereum) whitelistEndpoint() string {
+	if endpoint, ok := s.whitelistHeimdall.(interface{ Endpoint() string }); ok {
+		return redactURL(endpoint.Endpoint())
+	}
+
+	if s.config != nil {
+		return redactURL(s.config.HeimdallURL)
+	}
+
+	return ""
+}
+
+// whitelistHead returns the number of the current head block, as the target of
+// failed whitelisting attempts isn't known.
+func (s *Ethereum) whitelistHead() uint64 {
+	if s.blockchain == nil {
+		return 0
+	}
+
+	return s.blockchain.CurrentBlock().Number.Uint64()
+}
+
+// inWhitelistGracePeriod reports whether whitelisted checkpoints are not yet
+// enforced on peers.
+func (s *Ethereum) inWhitelistGracePeriod() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return time.Now().Before(s.whitelistGraceEnd)
+}
+
+// recordWhitelistResult keeps track of the outcome of the last checkpoint
+// whitelisting attempt, for health reporting. Rounds skipped during the grace
+// period are neither a success nor a failure, so they aren't recorded.
+func (s *Ethereum) recordWhitelistResult(err error) {
+	if errors.Is(err, errWhitelistGracePeriod) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.lastWhitelistErr = err
+	if err == nil {
+		s.lastWhitelist = time.Now()
+		s.markWhitelistReady()
+	}
+}
+
+// updateWhitelistLag reports the distance between the local head and the last
+// whitelisted checkpoint. The gauge is left untouched until a checkpoint gets
+// whitelisted, and goes negative while the node syncs up to it.
+func (s *Ethereum) updateWhitelistLag() {
+	if s.handler == nil || s.handler.downloader.ChainValidator == nil {
+		return
+	}
+
+	var (
+		last  uint64
+		found bool
+	)
+
+	for number := range s.handler.downloader.ChainValidator.GetCheckpointWhitelist() {
+		if !found || number > last {
+			last, found = number, true
+		}
+	}
+
+	if found {
+		whitelistLagGauge.Update(int64(s.whitelistHead()) - int64(last))
+	}
+}
+
+// restoreWhitelistedCheckpoint whitelists the latest checkpoint persisted in the
+// database, so it's enforced before the first heimdall round trip completes.
+func (s *Ethereum) restoreWhitelistedCheckpoint() {
+	number, hash, ok := rawdb.ReadLastWhitelistedCheckpoint(s.chainDb)
+	if !ok {
+		return
+	}
+
+	// Don't enforce a checkpoint on peers during the startup grace period
+	if s.config.WhitelistGracePeriod > 0 {
+		log.Info("Skipping persisted checkpoint during whitelist grace period", "number", number, "hash", hash)
+		return
+	}
+
+	ethHandler := (*ethHandler)(s.handler)
+	ethHandler.downloader.ProcessCheckpoint(number, hash)
+	s.handler.setCheckpointRequiredBlock(number, hash)
+
+	log.Info("Restored whitelisted checkpoint", "number", number, "hash", hash)
+}
+
+// checkpointVerification is the outcome of a checkpoint verification run.
+type checkpointVerification struct {
+	number   uint64      // End block of the last verified checkpoint
+	hash     common.Hash // Hash of the end block of the last verified checkpoint
+	verified bool        // Whether all fetched checkpoints were verified
+	err      error       // Error which stopped the verification, if any
+}
+
+// LastCheckpointVerification returns the outcome of the last checkpoint
+// verification: the end block number and hash of the last checkpoint which
+// passed verification, whether all fetched checkpoints did, and the error that
+// stopped the run otherwise.
+func (s *Ethereum) LastCheckpointVerification() (number uint64, hash common.Hash, verified bool, err error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	last := s.lastVerification
+
+	return last.number, last.hash, last.verified, last.err
+}
+
+// recordCheckpointVerification keeps track of the outcome of the last checkpoint
+// verification, given the verified checkpoints and the error stopping the run.
+func (s *Ethereum) recordCheckpointVerification(blockNums []uint64, blockHashes []common.Hash, err error) {
+	verification := checkpointVerification{
+		verified: err == nil,
+		err:      err,
+	}
+
+	if len(blockNums) > 0 {
+		verification.number = blockNums[len(blockNums)-1]
+		verification.hash = blockHashes[len(blockHashes)-1]
+	}
+
+	s.lock.Lock()
+	s.lastVerification = verification
+	s.lock.Unlock()
+}
+
 // handleWhitelistCheckpoint handles the checkpoint whitelist mechanism.
 func (s *Ethereum) handleWhitelistCheckpoint(ctx context.Context, first bool) error {
 	ethHandler := (*ethHandler)(s.handler)
@@ -702,9 +1941,35 @@ func (s *Ethereum) handleWhitelistCheckpoint(ctx context.Context, first bool) er
 		return ErrBorConsensusWithoutHeimdall
 	}
 
-	// Create a new checkpoint verifier
-	verifier := newCheckpointVerifier(nil)
-	blockNums, blockHashes, err := ethHandler.fetchWhitelistCheckpoints(ctx, bor, verifier, first)
+	heimdallClient := bor.HeimdallClient
+	if s.whitelistHeimdall != nil {
+		heimdallClient = s.whitelistHeimdall
+	}
+
+	return s.updateCheckpointWhitelist(ctx, heimdallClient, first)
+}
+
+// updateCheckpointWhitelist fetches the latest checkpoint/s from heimdall, verifies
+// them using the configured checkpoint verifier and whitelists the verified ones.
+func (s *Ethereum) updateCheckpointWhitelist(ctx context.Context, heimdallClient bor.IHeimdallClient, first bool) error {
+	ethHandler := (*ethHandler)(s.handler)
+
+	s.lock.RLock()
+	verifier := s.checkpointVerifier
+	s.lock.RUnlock()
+
+	if verifier == nil {
+		verifier = newCheckpointVerifier(nil)
+	}
+
+	workers := 1
+	if s.config != nil && s.config.WhitelistVerifyWorkers > 1 {
+		workers = s.config.WhitelistVerifyWorkers
+	}
+
+	blockNums, blockHashes, err := ethHandler.fetchWhitelistCheckpoints(ctx, heimdallClient, verifier, first, workers)
+	s.recordCheckpointVerification(blockNums, blockHashes, err)
+
 	// If the array is empty, we're bound to receive an error. Non-nill error and non-empty array
 	// means that array has partial elements and it failed for some block. We'll add those partial
 	// elements anyway.
@@ -712,14 +1977,50 @@ func (s *Ethereum) handleWhitelistCheckpoint(ctx context.Context, first bool) er
 		return err
 	}
 
+	// Don't reject peers during the grace period, giving a freshly started node
+	// time to find peers before enforcing a possibly stale checkpoint.
+	if s.inWhitelistGracePeriod() {
+		s.whitelistLog().Info("Checkpoint whitelist not enforced during grace period", "count", len(blockNums),
+			"number", blockNums[len(blockNums)-1], "hash", blockHashes[len(blockHashes)-1])
+
+		return errWhitelistGracePeriod
+	}
+
 	// Update the checkpoint whitelist map.
 	for i := 0; i < len(blockNums); i++ {
 		ethHandler.downloader.ProcessCheckpoint(blockNums[i], blockHashes[i])
 	}
 
+	// Require new peers to be on the chain of the latest checkpoint
+	s.handler.setCheckpointRequiredBlock(blockNums[len(blockNums)-1], blockHashes[len(blockHashes)-1])
+
+	if s.config != nil && s.config.PersistWhitelist && !s.readOnly {
+		rawdb.WriteLastWhitelistedCheckpoint(s.chainDb, blockNums[len(blockNums)-1], blockHashes[len(blockHashes)-1])
+	}
+
+	ev := CheckpointWhitelistEvent{
+		Number: blockNums[len(blockNums)-1],
+		Hash:   blockHashes[len(blockHashes)-1],
+		Count:  len(blockNums),
+	}
+	if s.eventMux != nil {
+		s.eventMux.Post(ev)
+	}
+
+	s.whitelistFeed.Send(ev)
+
+	s.whitelistLog().Debug("Whitelisted checkpoints", "count", len(blockNums),
+		"number", blockNums[len(blockNums)-1], "hash", blockHashes[len(blockHashes)-1], "err", err)
+
 	return nil
 }
 
+// SubscribeNewWhitelistedCheckpoint registers a subscription notified whenever
+// new bor checkpoints are whitelisted.
+func (s *Ethereum) SubscribeNewWhitelistedCheckpoint(ch chan<- CheckpointWhitelistEvent) event.Subscription {
+	return s.whitelistFeed.Subscribe(ch)
+}
+
 // Stop implements node.Lifecycle, terminating all internal goroutines used by the
 // Ethereum protocol.
 func (s *Ethereum) Stop() error {
@@ -735,15 +2036,22 @@ func (s *Ethereum) Stop() error {
 	// Close all bg processes
 	close(s.closeCh)
 
+	if s.whitelistHeimdall != nil {
+		s.whitelistHeimdall.Close()
+	}
+
 	// closing consensus engine first, as miner has deps on it
-	s.engine.Close()
+	s.closeEngine()
 	s.txPool.Stop()
-	s.miner.Close()
+	if s.miner != nil {
+		s.miner.Close()
+	}
 	s.blockchain.Stop()
-	s.engine.Close()
 
 	// Clean shutdown marker as the last thing before closing db
-	s.shutdownTracker.Stop()
+	if !s.readOnly {
+		s.shutdownTracker.Stop()
+	}
 
 	s.chainDb.Close()
 	s.eventMux.Stop()
@@ -751,6 +2059,16 @@ func (s *Ethereum) Stop() error {
 	return nil
 }
 
+// closeEngine closes the consensus engine, releasing resources such as the
+// heimdall client. Subsequent calls are no-ops.
+func (s *Ethereum) closeEngine() {
+	s.engineClose.Do(func() {
+		if err := s.engine.Close(); err != nil {
+			log.Warn("Failed to close consensus engine", "err", err)
+		}
+	})
+}
+
 //
 // Bor related methods
 //
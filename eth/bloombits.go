@@ -17,10 +17,13 @@
 package eth
 
 import (
+	"errors"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/bitutil"
+	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
 const (
@@ -28,6 +31,10 @@ const (
 	// instance to service bloombits lookups for all running filters.
 	bloomServiceThreads = 16
 
+	// bloomServiceQueue is the default number of bloom bit retrievals allowed to
+	// wait for a servicing goroutine before new ones are rejected.
+	bloomServiceQueue = 256
+
 	// bloomFilterThreads is the number of goroutines used locally per filter to
 	// multiplex requests onto the global servicing goroutines.
 	bloomFilterThreads = 3
@@ -41,17 +48,65 @@ const (
 	bloomRetrievalWait = time.Duration(0)
 )
 
+var (
+	// errBloomServiceBusy is returned to filters if the bloom bit retrieval
+	// queue is saturated.
+	errBloomServiceBusy = errors.New("bloom service busy, try again later")
+
+	bloomQueueGauge    = metrics.NewRegisteredGauge("eth/bloombits/queue", nil)
+	bloomRejectedMeter = metrics.NewRegisteredMeter("eth/bloombits/rejected", nil)
+)
+
 // startBloomHandlers starts a batch of goroutines to accept bloom bit database
 // retrievals from possibly a range of filters and serving the data to satisfy.
+// Retrievals are queued up to a configured limit, after which they are rejected
+// instead of blocking the requesting filter.
 func (eth *Ethereum) startBloomHandlers(sectionSize uint64) {
-	for i := 0; i < bloomServiceThreads; i++ {
+	threads := eth.config.BloomServiceThreads
+	if threads <= 0 {
+		threads = bloomServiceThreads
+	}
+
+	queueSize := eth.config.BloomServiceQueue
+	if queueSize <= 0 {
+		queueSize = bloomServiceQueue
+	}
+
+	queue := make(chan chan *bloombits.Retrieval, queueSize)
+
+	go func() {
+		for {
+			select {
+			case <-eth.closeBloomHandler:
+				return
+
+			case request := <-eth.bloomRequests:
+				select {
+				case queue <- request:
+					bloomQueueGauge.Update(int64(len(queue)))
+
+				default:
+					// Queue saturated, fail the retrieval right away
+					bloomRejectedMeter.Mark(1)
+
+					task := <-request
+					task.Error = errBloomServiceBusy
+					request <- task
+				}
+			}
+		}
+	}()
+
+	for i := 0; i < threads; i++ {
 		go func() {
 			for {
 				select {
 				case <-eth.closeBloomHandler:
 					return
 
-				case request := <-eth.bloomRequests:
+				case request := <-queue:
+					bloomQueueGauge.Update(int64(len(queue)))
+
 					task := <-request
 					task.Bitsets = make([][]byte, len(task.Sections))
 
@@ -0,0 +1,65 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/bloombits"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+)
+
+// Tests that bloom bit retrievals are rejected as busy once all servicing
+// goroutines are occupied and the bounded queue is full.
+func TestBloomServiceBusy(t *testing.T) {
+	t.Parallel()
+
+	eth := &Ethereum{
+		config:            &ethconfig.Config{BloomServiceThreads: 1, BloomServiceQueue: 1},
+		chainDb:           rawdb.NewMemoryDatabase(),
+		bloomRequests:     make(chan chan *bloombits.Retrieval),
+		closeBloomHandler: make(chan struct{}),
+	}
+	eth.startBloomHandlers(4096)
+
+	defer close(eth.closeBloomHandler)
+
+	// Occupy the only servicing goroutine by not picking up its result
+	busy := make(chan *bloombits.Retrieval)
+	eth.bloomRequests <- busy
+	busy <- &bloombits.Retrieval{}
+
+	// Fill up the queue, the next retrieval must be rejected
+	queued := make(chan *bloombits.Retrieval)
+	eth.bloomRequests <- queued
+
+	defer func() {
+		<-busy
+		queued <- &bloombits.Retrieval{}
+		<-queued
+	}()
+
+	rejected := make(chan *bloombits.Retrieval)
+	eth.bloomRequests <- rejected
+	rejected <- &bloombits.Retrieval{}
+
+	if task := <-rejected; !errors.Is(task.Error, errBloomServiceBusy) {
+		t.Fatalf("retrieval error mismatch: have %v, want %v", task.Error, errBloomServiceBusy)
+	}
+}
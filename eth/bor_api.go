@@ -0,0 +1,382 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// errBorLogsDisabled is returned when state-sync receipts are requested from a
+	// node not serving bor logs.
+	errBorLogsDisabled = errors.New("bor logs are disabled")
+
+	// errUnknownBlock is returned when a snapshot is requested for a block that
+	// isn't known locally.
+	errUnknownBlock = errors.New("unknown block")
+)
+
+// BorAPI provides bor specific node related RPC methods.
+type BorAPI struct {
+	e *Ethereum
+}
+
+// NewBorAPI creates a new BorAPI instance.
+func NewBorAPI(e *Ethereum) *BorAPI {
+	return &BorAPI{e}
+}
+
+// Diagnostics summarises the state relevant for block production on a bor node.
+type Diagnostics struct {
+	Engine             string         `json:"engine"`
+	Etherbase          common.Address `json:"etherbase"`
+	EtherbaseSet       bool           `json:"etherbaseSet"`
+	WalletFound        bool           `json:"walletFound"`
+	Authorized         bool           `json:"authorized"`
+	HeimdallConfigured bool           `json:"heimdallConfigured"`
+	LastWhitelist      *time.Time     `json:"lastWhitelist"`
+	LastWhitelistError string         `json:"lastWhitelistError,omitempty"`
+	SyncMode           string         `json:"syncMode"`
+	Synced             bool           `json:"synced"`
+	Mining             bool           `json:"mining"`
+}
+
+// Diagnostics returns a one-call health snapshot of the node's block production
+// prerequisites.
+func (api *BorAPI) Diagnostics() *Diagnostics {
+	return api.e.Diagnostics()
+}
+
+// ExportSnapshot returns the validator snapshot at the given block (or the
+// current head if none is given), including the proposer and sprint position.
+func (api *BorAPI) ExportSnapshot(number *rpc.BlockNumber) (*bor.ExportedSnapshot, error) {
+	engine, ok := api.e.borEngine()
+	if !ok {
+		return nil, ErrNotBorConsensus
+	}
+
+	var header *types.Header
+	if number == nil || *number == rpc.LatestBlockNumber {
+		header = api.e.blockchain.CurrentHeader()
+	} else {
+		header = api.e.blockchain.GetHeaderByNumber(uint64(number.Int64()))
+	}
+
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+
+	return engine.ExportSnapshot(api.e.blockchain, header)
+}
+
+// SpanInfo returns the current span at the chain head, when the next span
+// begins and the producers selected for the current one.
+func (api *BorAPI) SpanInfo(ctx context.Context) (*bor.SpanInfo, error) {
+	engine, ok := api.e.borEngine()
+	if !ok {
+		return nil, ErrNotBorConsensus
+	}
+
+	header := api.e.blockchain.CurrentHeader()
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+
+	return engine.SpanInfo(ctx, api.e.blockchain, header)
+}
+
+// GetBlockByNumber returns the requested block like eth_getBlockByNumber, with
+// the producer and the span number added on bor chains.
+func (api *BorAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+	block, err := ethapi.NewBlockChainAPI(api.e.APIBackend).GetBlockByNumber(ctx, number, fullTx)
+	if block == nil || err != nil {
+		return block, err
+	}
+
+	api.addBorBlockFields(ctx, block)
+
+	return block, nil
+}
+
+// GetBlockByHash returns the requested block like eth_getBlockByHash, with the
+// producer and the span number added on bor chains.
+func (api *BorAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (map[string]interface{}, error) {
+	block, err := ethapi.NewBlockChainAPI(api.e.APIBackend).GetBlockByHash(ctx, hash, fullTx)
+	if block == nil || err != nil {
+		return block, err
+	}
+
+	api.addBorBlockFields(ctx, block)
+
+	return block, nil
+}
+
+// addBorBlockFields adds the producer and the span of a marshalled block, as
+// derived from the bor engine. Fields which can't be derived are left out, and
+// the block is returned untouched on other engines.
+func (api *BorAPI) addBorBlockFields(ctx context.Context, block map[string]interface{}) {
+	engine, ok := api.e.borEngine()
+	if !ok {
+		return
+	}
+
+	hash, ok := block["hash"].(common.Hash)
+	if !ok {
+		return
+	}
+
+	header := api.e.blockchain.GetHeaderByHash(hash)
+	if header == nil {
+		return
+	}
+
+	if producer, err := engine.Author(header); err == nil {
+		block["producer"] = producer
+	} else {
+		log.Debug("Failed to recover block producer", "number", header.Number, "hash", hash, "err", err)
+	}
+
+	if span, err := engine.GetSpanner().GetCurrentSpan(ctx, hash); err == nil {
+		block["span"] = hexutil.Uint64(span.ID)
+	} else {
+		log.Debug("Failed to resolve block span", "number", header.Number, "hash", hash, "err", err)
+	}
+}
+
+// HeimdallCheckpoint is a checkpoint as returned by heimdall, along with its number.
+type HeimdallCheckpoint struct {
+	Number     int64                  `json:"number"`
+	Checkpoint *checkpoint.Checkpoint `json:"checkpoint"`
+}
+
+// LastHeimdallCheckpoint returns the latest raw checkpoint fetched from heimdall
+// by the checkpoint whitelisting service, as opposed to the derived whitelist
+// entry, to cross-validate the node against heimdall.
+func (api *BorAPI) LastHeimdallCheckpoint() (*HeimdallCheckpoint, error) {
+	cp, number := api.e.handler.lastHeimdallCheckpoint()
+	if cp == nil {
+		return nil, errNoHeimdallCheckpoint
+	}
+
+	return &HeimdallCheckpoint{Number: number, Checkpoint: cp}, nil
+}
+
+// RecentCheckpointRejections returns the most recent heimdall checkpoints
+// rejected by the whitelisting service along with the reason, oldest first.
+func (api *BorAPI) RecentCheckpointRejections() []CheckpointRejection {
+	return api.e.handler.recentCheckpointRejections()
+}
+
+// StateSyncEvent is a state-sync event record bridged from the root chain.
+type StateSyncEvent struct {
+	ID       uint64         `json:"id"`
+	Contract common.Address `json:"contract"`
+	Data     hexutil.Bytes  `json:"data"`
+	TxHash   common.Hash    `json:"txHash"`
+}
+
+// GetStateSyncEvents returns the state-sync events applied in the given block,
+// which is empty unless the block starts a sprint.
+func (api *BorAPI) GetStateSyncEvents(ctx context.Context, number rpc.BlockNumber) ([]*StateSyncEvent, error) {
+	if !api.e.config.BorLogs {
+		return nil, errBorLogsDisabled
+	}
+
+	engine, ok := api.e.borEngine()
+	if !ok {
+		return nil, ErrNotBorConsensus
+	}
+
+	var header *types.Header
+	if number == rpc.LatestBlockNumber {
+		header = api.e.blockchain.CurrentHeader()
+	} else {
+		header = api.e.blockchain.GetHeaderByNumber(uint64(number.Int64()))
+	}
+
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+
+	stateSyncs, err := engine.StateSyncEvents(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*StateSyncEvent, 0, len(stateSyncs))
+
+	for _, stateSync := range stateSyncs {
+		data, err := hex.DecodeString(stateSync.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, &StateSyncEvent{
+			ID:       stateSync.ID,
+			Contract: stateSync.Contract,
+			Data:     data,
+			TxHash:   stateSync.TxHash,
+		})
+	}
+
+	return events, nil
+}
+
+// Diagnostics collects a snapshot of the node's block production prerequisites.
+// Unlike Etherbase, it only reports the configured etherbase and never picks
+// one automatically.
+func (s *Ethereum) Diagnostics() *Diagnostics {
+	diag := &Diagnostics{
+		Engine:   fmt.Sprintf("%T", s.engine),
+		SyncMode: s.SyncMode().String(),
+		Synced:   s.Synced(),
+		Mining:   s.IsMining(),
+	}
+
+	if engine, ok := s.borEngine(); ok {
+		diag.HeimdallConfigured = engine.HeimdallClient != nil
+	}
+
+	s.lock.RLock()
+	etherbase := s.etherbase
+	diag.Authorized = s.authorized
+
+	if !s.lastWhitelist.IsZero() {
+		last := s.lastWhitelist
+		diag.LastWhitelist = &last
+	}
+
+	if s.lastWhitelistErr != nil {
+		diag.LastWhitelistError = s.lastWhitelistErr.Error()
+	}
+	s.lock.RUnlock()
+
+	if etherbase != (common.Address{}) {
+		diag.Etherbase = etherbase
+		diag.EtherbaseSet = true
+
+		wallet, err := s.accountManager.Find(accounts.Account{Address: etherbase})
+		diag.WalletFound = wallet != nil && err == nil
+	}
+
+	return diag
+}
+
+// borEngine returns the bor engine of the node, unwrapping it from the beacon
+// engine if needed.
+func (s *Ethereum) borEngine() (*bor.Bor, bool) {
+	engine, ok := s.sealingEngine().(*bor.Bor)
+	return engine, ok
+}
+
+// CallAtSpan executes eth_call against the state at the first block of the given
+// validator span, as committed in the validator set contract. It's only
+// available on bor and fails for spans not reached by the local chain yet.
+func (api *EthereumAPI) CallAtSpan(ctx context.Context, args ethapi.TransactionArgs, span hexutil.Uint64, overrides *ethapi.StateOverride) (hexutil.Bytes, error) {
+	engine, ok := api.e.borEngine()
+	if !ok {
+		return nil, ErrNotBorConsensus
+	}
+
+	head := api.e.blockchain.CurrentHeader()
+
+	start, err := engine.SpanStartBlock(ctx, uint64(span), head)
+	if err != nil {
+		return nil, err
+	}
+
+	if start > head.Number.Uint64() {
+		return nil, fmt.Errorf("%w: span %d starts at block %d, head is %d", errUnknownBlock, span, start, head.Number.Uint64())
+	}
+
+	blockNr := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(start))
+
+	return ethapi.NewBlockChainAPI(api.e.APIBackend).Call(ctx, args, blockNr, overrides)
+}
+
+// GetBorBlockReceiptByNumber returns the state-sync receipt of the given block in
+// the same shape as a regular transaction receipt, sparing clients from deriving
+// the bor transaction hash. A nil result is returned for blocks without state-sync.
+func (api *EthereumAPI) GetBorBlockReceiptByNumber(ctx context.Context, number rpc.BlockNumber) (map[string]interface{}, error) {
+	if !api.e.config.BorLogs {
+		return nil, errBorLogsDisabled
+	}
+
+	header, err := api.e.APIBackend.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if header == nil {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+
+	var (
+		blockHash   = header.Hash()
+		blockNumber = header.Number.Uint64()
+	)
+
+	receipt := rawdb.ReadBorReceipt(api.e.chainDb, blockHash, blockNumber, api.e.blockchain.Config())
+	if receipt == nil {
+		return nil, nil
+	}
+
+	txHash := types.GetDerivedBorTxHash(types.BorReceiptKey(blockNumber, blockHash))
+
+	tx, _, _, index := rawdb.ReadBorTransactionWithBlockHash(api.e.chainDb, txHash, blockHash)
+	if tx == nil {
+		return nil, nil
+	}
+
+	fields := map[string]interface{}{
+		"blockHash":         blockHash,
+		"blockNumber":       hexutil.Uint64(blockNumber),
+		"transactionHash":   txHash,
+		"transactionIndex":  hexutil.Uint64(index),
+		"from":              common.Address{},
+		"to":                tx.To(),
+		"gasUsed":           hexutil.Uint64(receipt.GasUsed),
+		"cumulativeGasUsed": hexutil.Uint64(receipt.CumulativeGasUsed),
+		"contractAddress":   nil,
+		"logs":              receipt.Logs,
+		"logsBloom":         receipt.Bloom,
+		"type":              hexutil.Uint(tx.Type()),
+		"effectiveGasPrice": (*hexutil.Big)(receipt.EffectiveGasPrice),
+		"status":            hexutil.Uint(receipt.Status),
+	}
+
+	if receipt.Logs == nil {
+		fields["logs"] = []*types.Log{}
+	}
+
+	return fields, nil
+}
@@ -2,7 +2,6 @@ package eth
 
 import (
 	"context"
-	"errors"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -24,7 +23,7 @@ func (b *EthAPIBackend) GetRootHash(ctx context.Context, starBlockNr uint64, end
 	}
 
 	if api == nil {
-		return "", errors.New("Only available in Bor engine")
+		return "", ErrNotBorConsensus
 	}
 
 	root, err := api.GetRootHash(starBlockNr, endBlockNr)
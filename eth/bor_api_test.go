@@ -0,0 +1,276 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that the diagnostics see through a beacon wrapped bor engine and report
+// the configured etherbase without picking one automatically.
+func TestDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+
+	account, err := ks.NewAccount("")
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	var (
+		gspec    = &core.Genesis{Config: params.TestChainConfig}
+		chain, _ = core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	)
+	defer chain.Stop()
+
+	h := &handler{chain: chain}
+	h.chainSync = newChainSyncer(h)
+
+	eth := &Ethereum{
+		config:         &ethconfig.Config{Miner: miner.Config{AutoEtherbase: true}},
+		engine:         beacon.New(&bor.Bor{HeimdallClient: &mockHeimdall{}}),
+		accountManager: accounts.NewManager(&accounts.Config{}, ks),
+		handler:        h,
+	}
+	defer eth.accountManager.Close()
+
+	diag := eth.Diagnostics()
+	if !diag.HeimdallConfigured {
+		t.Fatalf("heimdall client of the wrapped bor engine not reported")
+	}
+
+	if diag.EtherbaseSet || diag.WalletFound || diag.Etherbase != (common.Address{}) {
+		t.Fatalf("unset etherbase reported: have %+v", diag)
+	}
+
+	if eth.etherbase != (common.Address{}) {
+		t.Fatalf("etherbase picked automatically: have %v", eth.etherbase)
+	}
+
+	if diag.Mining || diag.Synced {
+		t.Fatalf("idle node reported as mining or synced: have %+v", diag)
+	}
+
+	eth.lock.Lock()
+	eth.etherbase = account.Address
+	eth.lock.Unlock()
+
+	diag = eth.Diagnostics()
+	if !diag.EtherbaseSet || !diag.WalletFound || diag.Etherbase != account.Address {
+		t.Fatalf("configured etherbase mismatch: have %+v, want %v", diag, account.Address)
+	}
+}
+
+// Tests that the bor engine is found even if wrapped into the beacon engine.
+func TestBorEngine(t *testing.T) {
+	t.Parallel()
+
+	inner := new(bor.Bor)
+
+	for _, eth := range []*Ethereum{{engine: inner}, {engine: beacon.New(inner)}} {
+		if engine, ok := eth.borEngine(); !ok || engine != inner {
+			t.Fatalf("bor engine mismatch for %T: have %v, want %v", eth.engine, engine, inner)
+		}
+	}
+
+	eth := &Ethereum{engine: ethash.NewFaker()}
+	if _, ok := eth.borEngine(); ok {
+		t.Fatalf("bor engine found on ethash")
+	}
+}
+
+// Tests that the state-sync receipt of a block is returned in the shape of a
+// regular receipt, and that blocks without state-sync yield an empty result.
+func TestGetBorBlockReceiptByNumber(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		gspec    = &core.Genesis{Config: params.TestChainConfig}
+		_, bs, _ = core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, nil)
+		chain, _ = core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	)
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(bs); err != nil {
+		t.Fatalf("failed to import blocks: %v", err)
+	}
+
+	// Attach a state-sync receipt to the first block only
+	var (
+		block  = bs[0]
+		txHash = types.GetDerivedBorTxHash(types.BorReceiptKey(block.NumberU64(), block.Hash()))
+		event  = &types.Log{Address: common.HexToAddress("0x1001"), Data: []byte{0x01}}
+	)
+
+	rawdb.WriteBorReceipt(db, block.Hash(), block.NumberU64(), &types.ReceiptForStorage{
+		Status: types.ReceiptStatusSuccessful,
+		Logs:   []*types.Log{event},
+	})
+	rawdb.WriteBorTxLookupEntry(db, block.Hash(), block.NumberU64())
+
+	eth := &Ethereum{
+		config:     &ethconfig.Config{BorLogs: true},
+		blockchain: chain,
+		chainDb:    db,
+	}
+	eth.APIBackend = &EthAPIBackend{eth: eth}
+
+	api := NewEthereumAPI(eth)
+
+	fields, err := api.GetBorBlockReceiptByNumber(context.Background(), rpc.BlockNumber(1))
+	if err != nil {
+		t.Fatalf("failed to retrieve state-sync receipt: %v", err)
+	}
+
+	if fields == nil {
+		t.Fatalf("state-sync receipt missing")
+	}
+
+	if have := fields["transactionHash"]; have != txHash {
+		t.Fatalf("transaction hash mismatch: have %v, want %v", have, txHash)
+	}
+
+	if have := fields["blockHash"]; have != block.Hash() {
+		t.Fatalf("block hash mismatch: have %v, want %v", have, block.Hash())
+	}
+
+	logs, ok := fields["logs"].([]*types.Log)
+	if !ok || len(logs) != 1 {
+		t.Fatalf("logs mismatch: have %v, want 1 log", fields["logs"])
+	}
+
+	if logs[0].TxHash != txHash || logs[0].BlockNumber != block.NumberU64() {
+		t.Fatalf("log fields not derived: have %+v", logs[0])
+	}
+
+	// The second block has no state-sync, which isn't an error
+	fields, err = api.GetBorBlockReceiptByNumber(context.Background(), rpc.BlockNumber(2))
+	if err != nil || fields != nil {
+		t.Fatalf("receipt of block without state-sync mismatch: have %v, %v, want nil", fields, err)
+	}
+
+	// Nodes not serving bor logs refuse the request
+	eth.config.BorLogs = false
+
+	if _, err := api.GetBorBlockReceiptByNumber(context.Background(), rpc.BlockNumber(1)); !errors.Is(err, errBorLogsDisabled) {
+		t.Fatalf("error mismatch with bor logs disabled: have %v, want %v", err, errBorLogsDisabled)
+	}
+}
+
+// Tests that the producer and span of a block are added on bor chains, that
+// fields which can't be derived are left out, and that other engines leave the
+// block untouched.
+func TestAddBorBlockFields(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		gspec    = &core.Genesis{Config: params.TestChainConfig}
+		chain, _ = core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+		config   = params.BorUnittestChainConfig
+	)
+	defer chain.Stop()
+
+	// Store a header sealed by the test account
+	header := &types.Header{
+		ParentHash: chain.Genesis().Hash(),
+		Number:     big.NewInt(1),
+		Difficulty: big.NewInt(1),
+		Extra:      make([]byte, types.ExtraVanityLength+types.ExtraSealLength),
+	}
+
+	sig, err := crypto.Sign(bor.SealHash(header, config.Bor).Bytes(), testKey)
+	if err != nil {
+		t.Fatalf("failed to seal header: %v", err)
+	}
+
+	copy(header.Extra[types.ExtraVanityLength:], sig)
+	rawdb.WriteHeader(db, header)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	spanner := bor.NewMockSpanner(ctrl)
+	gomock.InOrder(
+		spanner.EXPECT().GetCurrentSpan(gomock.Any(), header.Hash()).Return(&span.Span{ID: 7}, nil),
+		spanner.EXPECT().GetCurrentSpan(gomock.Any(), header.Hash()).Return(nil, errors.New("span unavailable")),
+	)
+
+	api := NewBorAPI(&Ethereum{
+		blockchain: chain,
+		engine:     bor.New(config, db, nil, spanner, nil, nil, false),
+	})
+
+	block := map[string]interface{}{"hash": header.Hash()}
+	api.addBorBlockFields(context.Background(), block)
+
+	if have := block["producer"]; have != testAddr {
+		t.Fatalf("producer mismatch: have %v, want %v", have, testAddr)
+	}
+
+	if have := block["span"]; have != hexutil.Uint64(7) {
+		t.Fatalf("span mismatch: have %v, want %v", have, hexutil.Uint64(7))
+	}
+
+	// A span failing to resolve is left out
+	block = map[string]interface{}{"hash": header.Hash()}
+	api.addBorBlockFields(context.Background(), block)
+
+	if _, ok := block["span"]; ok || block["producer"] != testAddr {
+		t.Fatalf("fields mismatch with unavailable span: have %v", block)
+	}
+
+	// Unknown blocks and other engines are left untouched
+	block = map[string]interface{}{"hash": common.Hash{0x01}}
+	api.addBorBlockFields(context.Background(), block)
+
+	if len(block) != 1 {
+		t.Fatalf("unknown block modified: have %v", block)
+	}
+
+	block = map[string]interface{}{"hash": header.Hash()}
+	NewBorAPI(&Ethereum{blockchain: chain, engine: ethash.NewFaker()}).addBorBlockFields(context.Background(), block)
+
+	if len(block) != 1 {
+		t.Fatalf("block modified on ethash: have %v", block)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// chainConfigSpanID is the span fetched from heimdall to cross-check the
+	// chain config, being the first span produced after genesis.
+	chainConfigSpanID = 1
+
+	// chainConfigVerifyTimeout bounds the heimdall request, as the client retries
+	// until its context is done.
+	chainConfigVerifyTimeout = 30 * time.Second
+)
+
+var (
+	// ErrChainConfigMismatch is returned when the local chain config disagrees
+	// with the parameters reported by heimdall.
+	ErrChainConfigMismatch = errors.New("chain config mismatch with heimdall")
+)
+
+// verifyChainConfigWithHeimdall cross-checks the chain ID, sprint length and span
+// alignment of the given chain config against the span reported by heimdall.
+func verifyChainConfigWithHeimdall(ctx context.Context, chainConfig *params.ChainConfig, heimdallClient bor.IHeimdallClient) error {
+	if chainConfig.Bor == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, chainConfigVerifyTimeout)
+	defer cancel()
+
+	span, err := heimdallClient.Span(ctx, chainConfigSpanID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch span %d from heimdall: %w", chainConfigSpanID, err)
+	}
+
+	if span == nil {
+		return fmt.Errorf("span %d not found on heimdall", chainConfigSpanID)
+	}
+
+	if chainConfig.ChainID == nil || span.ChainID != chainConfig.ChainID.String() {
+		return fmt.Errorf("%w: chain id local %v, heimdall %s", ErrChainConfigMismatch, chainConfig.ChainID, span.ChainID)
+	}
+
+	if span.EndBlock < span.StartBlock {
+		return fmt.Errorf("%w: invalid span %d range [%d, %d]", ErrChainConfigMismatch, span.ID, span.StartBlock, span.EndBlock)
+	}
+
+	sprint := chainConfig.Bor.CalculateSprint(span.StartBlock)
+	if sprint == 0 {
+		return fmt.Errorf("%w: sprint length not configured at block %d", ErrChainConfigMismatch, span.StartBlock)
+	}
+
+	if span.StartBlock%sprint != 0 {
+		return fmt.Errorf("%w: span %d starts at block %d, not aligned to sprint length %d", ErrChainConfigMismatch, span.ID, span.StartBlock, sprint)
+	}
+
+	if length := span.EndBlock - span.StartBlock + 1; length%sprint != 0 {
+		return fmt.Errorf("%w: span %d length %d is not a multiple of sprint length %d", ErrChainConfigMismatch, span.ID, length, sprint)
+	}
+
+	return nil
+}
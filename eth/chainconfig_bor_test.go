@@ -0,0 +1,64 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestVerifyChainConfigWithHeimdall(t *testing.T) {
+	t.Parallel()
+
+	chainConfig := &params.ChainConfig{
+		ChainID: big.NewInt(137),
+		Bor:     &params.BorConfig{Sprint: map[string]uint64{"0": 64}},
+	}
+
+	testCases := []struct {
+		name     string
+		span     *span.HeimdallSpan
+		expected error
+	}{
+		{"matching config", &span.HeimdallSpan{Span: span.Span{ID: 1, StartBlock: 256, EndBlock: 6655}, ChainID: "137"}, nil},
+		{"chain id mismatch", &span.HeimdallSpan{Span: span.Span{ID: 1, StartBlock: 256, EndBlock: 6655}, ChainID: "80001"}, ErrChainConfigMismatch},
+		{"unaligned span start", &span.HeimdallSpan{Span: span.Span{ID: 1, StartBlock: 250, EndBlock: 6649}, ChainID: "137"}, ErrChainConfigMismatch},
+		{"unaligned span length", &span.HeimdallSpan{Span: span.Span{ID: 1, StartBlock: 256, EndBlock: 6600}, ChainID: "137"}, ErrChainConfigMismatch},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			heimdall := &mockHeimdall{
+				span: func(_ context.Context, _ uint64) (*span.HeimdallSpan, error) {
+					return tc.span, nil
+				},
+			}
+
+			if err := verifyChainConfigWithHeimdall(context.Background(), chainConfig, heimdall); !errors.Is(err, tc.expected) {
+				t.Fatalf("error mismatch: have %v, want %v", err, tc.expected)
+			}
+		})
+	}
+}
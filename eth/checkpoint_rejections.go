@@ -0,0 +1,141 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+)
+
+// Reasons heimdall checkpoints get rejected by the whitelisting service.
+const (
+	RejectionHashMismatch      = "hash mismatch"      // The local root hash differs from the checkpoint's
+	RejectionHeaderUnavailable = "header unavailable" // The checkpoint blocks aren't available locally (yet)
+	RejectionNetworkError      = "network error"      // Heimdall couldn't be reached
+	RejectionOther             = "other"
+)
+
+// CheckpointRejection is a heimdall checkpoint rejected by the whitelisting
+// service, along with the reason why.
+type CheckpointRejection struct {
+	Time       time.Time   `json:"time"`
+	Number     int64       `json:"number"`               // Heimdall checkpoint number, 0 if not known yet
+	StartBlock uint64      `json:"startBlock,omitempty"` // First block of the checkpoint, if it was fetched
+	EndBlock   uint64      `json:"endBlock,omitempty"`   // Last block of the checkpoint, if it was fetched
+	RootHash   common.Hash `json:"rootHash"`             // Root hash of the checkpoint, zero if it wasn't fetched
+	Reason     string      `json:"reason"`
+	Error      string      `json:"error"`
+}
+
+// checkpointRejections is a bounded ring buffer of the most recent checkpoint
+// rejections. A zero sized buffer records nothing.
+type checkpointRejections struct {
+	entries []CheckpointRejection
+	next    int // Index of the slot overwritten next once the buffer is full
+	size    int
+	lock    sync.Mutex
+}
+
+// newCheckpointRejections creates a buffer keeping the given number of the most
+// recent checkpoint rejections.
+func newCheckpointRejections(size int) *checkpointRejections {
+	if size < 0 {
+		size = 0
+	}
+
+	return &checkpointRejections{
+		entries: make([]CheckpointRejection, 0, size),
+		size:    size,
+	}
+}
+
+// add records the rejection of the checkpoint with the given number, which may
+// be nil if it couldn't be fetched, evicting the oldest one if full.
+func (r *checkpointRejections) add(number int64, cp *checkpoint.Checkpoint, err error) {
+	if r == nil || r.size == 0 {
+		return
+	}
+
+	rejection := CheckpointRejection{
+		Time:   time.Now(),
+		Number: number,
+		Reason: checkpointRejectionReason(err),
+		Error:  err.Error(),
+	}
+
+	if cp != nil {
+		if cp.StartBlock != nil {
+			rejection.StartBlock = cp.StartBlock.Uint64()
+		}
+
+		if cp.EndBlock != nil {
+			rejection.EndBlock = cp.EndBlock.Uint64()
+		}
+
+		rejection.RootHash = cp.RootHash
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.entries) < r.size {
+		r.entries = append(r.entries, rejection)
+		return
+	}
+
+	r.entries[r.next] = rejection
+	r.next = (r.next + 1) % r.size
+}
+
+// list returns the recorded rejections, oldest first.
+func (r *checkpointRejections) list() []CheckpointRejection {
+	if r == nil {
+		return []CheckpointRejection{}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	list := make([]CheckpointRejection, 0, len(r.entries))
+	list = append(list, r.entries[r.next:]...)
+	list = append(list, r.entries[:r.next]...)
+
+	return list
+}
+
+// checkpointRejectionReason classifies the error a checkpoint got rejected with.
+func checkpointRejectionReason(err error) string {
+	switch {
+	case errors.Is(err, errCheckpointRootHashMismatch):
+		return RejectionHashMismatch
+
+	case errors.Is(err, errMissingCheckpoint), errors.Is(err, errRootHash), errors.Is(err, errEndBlock):
+		return RejectionHeaderUnavailable
+
+	case errors.Is(err, errCheckpoint), errors.Is(err, errCheckpointCount),
+		errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return RejectionNetworkError
+
+	default:
+		return RejectionOther
+	}
+}
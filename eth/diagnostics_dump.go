@@ -0,0 +1,142 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// errDiagnosticsFileExists is returned when the diagnostics report would
+// overwrite an existing file.
+var errDiagnosticsFileExists = errors.New("location would overwrite an existing file")
+
+// DiagnosticsReport is a support oriented snapshot of the node, combining the
+// sync, peer, whitelist and mining state with the effective configuration.
+type DiagnosticsReport struct {
+	Time                 time.Time             `json:"time"`
+	Version              string                `json:"version"`
+	Head                 DiagnosticsHead       `json:"head"`
+	Sync                 DiagnosticsSync       `json:"sync"`
+	Peers                []*PeerProtocols      `json:"peers"`
+	Whitelist            DiagnosticsWhitelist  `json:"whitelist"`
+	Mining               *Diagnostics          `json:"mining"`
+	MiningPaused         bool                  `json:"miningPaused"`
+	Config               *ethconfig.Config     `json:"config"` // Effective configuration, secrets redacted
+	CheckpointRejections []CheckpointRejection `json:"checkpointRejections"`
+}
+
+// DiagnosticsHead identifies the current head block.
+type DiagnosticsHead struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// DiagnosticsSync describes the sync state of the node.
+type DiagnosticsSync struct {
+	Mode     string                `json:"mode"`
+	Synced   bool                  `json:"synced"`
+	Progress ethereum.SyncProgress `json:"progress"`
+}
+
+// DiagnosticsWhitelist describes the state of the checkpoint whitelisting.
+type DiagnosticsWhitelist struct {
+	Checkpoints            []WhitelistEntry    `json:"checkpoints"`
+	Stale                  bool                `json:"stale"`
+	LastVerifiedNumber     uint64              `json:"lastVerifiedNumber"`
+	LastVerifiedHash       common.Hash         `json:"lastVerifiedHash"`
+	LastVerificationPassed bool                `json:"lastVerificationPassed"`
+	LastVerificationError  string              `json:"lastVerificationError,omitempty"`
+	LastHeimdallCheckpoint *HeimdallCheckpoint `json:"lastHeimdallCheckpoint,omitempty"`
+}
+
+// DiagnosticsReport collects a diagnostics report of the node. Every section is
+// gathered under its own locks, so the report is safe to assemble while the
+// node operates, though the sections may be a few moments apart.
+func (s *Ethereum) DiagnosticsReport() *DiagnosticsReport {
+	head := s.blockchain.CurrentBlock()
+
+	report := &DiagnosticsReport{
+		Time:    time.Now(),
+		Version: params.VersionWithMeta,
+		Head:    DiagnosticsHead{Number: head.Number.Uint64(), Hash: head.Hash()},
+		Sync: DiagnosticsSync{
+			Mode:     s.SyncMode().String(),
+			Synced:   s.Synced(),
+			Progress: s.Downloader().Progress(),
+		},
+		Peers:                s.PeerProtocols(),
+		Mining:               s.Diagnostics(),
+		MiningPaused:         s.miner != nil && s.miner.Paused(),
+		Config:               s.EffectiveConfig(),
+		CheckpointRejections: s.handler.recentCheckpointRejections(),
+	}
+
+	if export, err := s.ExportWhitelist(); err == nil {
+		report.Whitelist.Checkpoints = export.Checkpoints
+	}
+
+	report.Whitelist.Stale = s.whitelistStale()
+
+	number, hash, verified, err := s.LastCheckpointVerification()
+	report.Whitelist.LastVerifiedNumber = number
+	report.Whitelist.LastVerifiedHash = hash
+	report.Whitelist.LastVerificationPassed = verified
+
+	if err != nil {
+		report.Whitelist.LastVerificationError = err.Error()
+	}
+
+	if cp, number := s.handler.lastHeimdallCheckpoint(); cp != nil {
+		report.Whitelist.LastHeimdallCheckpoint = &HeimdallCheckpoint{Number: number, Checkpoint: cp}
+	}
+
+	return report
+}
+
+// writeDiagnosticsReport writes the report as indented JSON into a new file,
+// readable by the owner only as it describes the node's setup. Existing files
+// are never overwritten, as the path may point anywhere on the drive.
+func writeDiagnosticsReport(path string, report *DiagnosticsReport) error {
+	blob, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return errDiagnosticsFileExists
+		}
+
+		return err
+	}
+
+	if _, err := out.Write(blob); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// diskGuardInterval is the interval at which the free disk space of the
+	// chain database is checked.
+	diskGuardInterval = 30 * time.Second
+
+	// errDiskSpaceLow is returned when trying to resume mining while it's paused
+	// for lack of disk space.
+	errDiskSpaceLow = errors.New("mining paused, free disk space below minimum")
+)
+
+// DiskStatus describes the free disk space of the chain database and whether
+// block import and mining are paused because of it.
+type DiskStatus struct {
+	Path        string    `json:"path"`
+	Free        uint64    `json:"free"`        // Free disk space in bytes at the last check
+	MinFree     uint64    `json:"minFree"`     // Free disk space in bytes below which the node pauses
+	Paused      bool      `json:"paused"`      // Whether block import and mining are paused
+	LastChecked time.Time `json:"lastChecked"` // Time of the last check, zero if the guard is disabled
+	Error       string    `json:"error,omitempty"`
+}
+
+// startDiskGuard periodically checks the free disk space of the chain database,
+// pausing block import and mining while it's below the configured minimum and
+// resuming them once space frees up. It's a no-op unless a minimum is set.
+func (s *Ethereum) startDiskGuard() {
+	if s.config.MinFreeDiskSpace == 0 {
+		return
+	}
+
+	log.Info("Watching free disk space of the chain database", "path", s.chaindataPath,
+		"minimum", common.StorageSize(s.minFreeDiskSpace()))
+
+	s.checkDiskGuard()
+
+	go func() {
+		ticker := time.NewTicker(diskGuardInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.checkDiskGuard()
+
+			case <-s.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// minFreeDiskSpace returns the configured minimum free disk space in bytes.
+func (s *Ethereum) minFreeDiskSpace() uint64 {
+	return s.config.MinFreeDiskSpace * 1024 * 1024
+}
+
+// checkDiskGuard measures the free disk space of the chain database, pausing
+// or resuming block import and mining accordingly.
+func (s *Ethereum) checkDiskGuard() {
+	s.updateDiskGuard(freeDiskSpace(s.chaindataPath))
+}
+
+// updateDiskGuard pauses or resumes block import and mining based on the free
+// disk space measured. Failing measurements leave the current state in place.
+func (s *Ethereum) updateDiskGuard(free uint64, err error) {
+	s.lock.Lock()
+
+	s.diskStatus.LastChecked = time.Now()
+
+	if err != nil {
+		s.diskStatus.Error = err.Error()
+		s.lock.Unlock()
+
+		log.Warn("Failed to get free disk space", "path", s.chaindataPath, "err", err)
+
+		return
+	}
+
+	s.diskStatus.Free, s.diskStatus.Error = free, ""
+
+	var (
+		low     = free < s.minFreeDiskSpace()
+		resumed = !low && s.diskStatus.Paused
+	)
+
+	switch {
+	case low && !s.diskStatus.Paused:
+		s.blockchain.PauseInsert()
+
+	case resumed:
+		s.blockchain.ResumeInsert()
+	}
+
+	s.diskStatus.Paused = low
+	s.lock.Unlock()
+
+	// Update the miner without the lock held, as it may block until the miner
+	// loop gets around to it
+	switch {
+	case low:
+		// Re-pause on every check, as an explicit miner start overrides the pause
+		s.holdMining(miningHoldDiskSpace)
+
+		log.Error("Low disk space, block import and mining paused until space frees up", "path", s.chaindataPath,
+			"available", common.StorageSize(free), "minimum", common.StorageSize(s.minFreeDiskSpace()))
+
+	case resumed:
+		s.releaseMining(miningHoldDiskSpace)
+
+		log.Info("Disk space recovered, resuming block import", "path", s.chaindataPath,
+			"available", common.StorageSize(free))
+	}
+}
+
+// DiskStatus returns the free disk space of the chain database as of the last
+// check, and whether the node is paused because of it.
+func (s *Ethereum) DiskStatus() *DiskStatus {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	status := s.diskStatus
+	status.Path = s.chaindataPath
+	status.MinFree = s.minFreeDiskSpace()
+
+	return &status
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that block import is paused while the free disk space is below the
+// configured minimum, and resumed once it frees up.
+func TestDiskGuard(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gspec    = &core.Genesis{Config: params.TestChainConfig}
+		chain, _ = core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+		_, bs, _ = core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, nil)
+	)
+	defer chain.Stop()
+
+	s := &Ethereum{
+		config:        &ethconfig.Config{MinFreeDiskSpace: 1},
+		blockchain:    chain,
+		chaindataPath: "chaindata",
+	}
+
+	s.updateDiskGuard(512*1024, nil)
+
+	if !s.DiskStatus().Paused {
+		t.Fatalf("guard not paused on low disk space")
+	}
+
+	if _, err := chain.InsertChain(bs[:1]); !errors.Is(err, core.ErrInsertPaused) {
+		t.Fatalf("paused import error mismatch: have %v, want %v", err, core.ErrInsertPaused)
+	}
+
+	// Failing measurements must not resume the node
+	s.updateDiskGuard(0, errors.New("statfs failed"))
+
+	if status := s.DiskStatus(); !status.Paused || status.Error == "" {
+		t.Fatalf("status after failed check mismatch: have %+v", status)
+	}
+
+	s.updateDiskGuard(2*1024*1024, nil)
+
+	status := s.DiskStatus()
+	if status.Paused || status.Free != 2*1024*1024 || status.MinFree != 1024*1024 || status.Error != "" {
+		t.Fatalf("status after recovery mismatch: have %+v", status)
+	}
+
+	if _, err := chain.InsertChain(bs); err != nil {
+		t.Fatalf("failed to import after recovery: %v", err)
+	}
+}
+
+// Tests that the disk guard only lifts its own hold on the miner, leaving a
+// manual pause in place, and that it doesn't block on a closed miner.
+func TestDiskGuardMiningHolds(t *testing.T) {
+	t.Parallel()
+
+	s := newMiningTestBackend(t, &ethconfig.Config{MinFreeDiskSpace: 1})
+
+	// A manual pause survives the disk space recovering
+	if err := s.PauseMining(); err != nil {
+		t.Fatalf("failed to pause mining: %v", err)
+	}
+
+	s.updateDiskGuard(512*1024, nil)
+	s.updateDiskGuard(2*1024*1024, nil)
+
+	if !s.miner.Paused() {
+		t.Fatalf("manual pause lifted by the disk guard")
+	}
+
+	// Resuming manually is refused while the disk space is low
+	s.updateDiskGuard(512*1024, nil)
+
+	if err := s.ResumeMining(); !errors.Is(err, errDiskSpaceLow) {
+		t.Fatalf("resume error mismatch: have %v, want %v", err, errDiskSpaceLow)
+	}
+
+	if !s.miner.Paused() {
+		t.Fatalf("mining resumed while disk space is low")
+	}
+
+	s.updateDiskGuard(2*1024*1024, nil)
+
+	if s.miner.Paused() {
+		t.Fatalf("mining still paused after all holds got lifted")
+	}
+
+	// A closed miner must not block the guard
+	s.miner.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		s.updateDiskGuard(512*1024, nil)
+		s.updateDiskGuard(2*1024*1024, nil)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("disk guard blocked on a closed miner")
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// DNSDiscoveryStatus describes the nodes yielded by a single ENR tree. The DNS
+// client doesn't expose when a tree was last synced, so only the time the tree
+// last yielded a node is reported.
+type DNSDiscoveryStatus struct {
+	Protocol  string     `json:"protocol"`
+	URL       string     `json:"url"`
+	Nodes     uint64     `json:"nodes"`     // Number of distinct nodes yielded by the tree
+	Yields    uint64     `json:"yields"`    // Number of nodes yielded by the tree, including repeats
+	LastYield *time.Time `json:"lastYield"` // Time the tree last yielded a node
+}
+
+// dnsIterator wraps the DNS discovery iterator of a single ENR tree, tracking
+// the nodes it yields.
+type dnsIterator struct {
+	enode.Iterator
+
+	protocol string
+	url      string
+
+	lock   sync.Mutex
+	seen   map[enode.ID]struct{} // Distinct nodes yielded by the tree
+	yields uint64
+	last   time.Time
+}
+
+// newDNSIterator wraps the iterator of the given ENR tree.
+func newDNSIterator(it enode.Iterator, protocol string, url string) *dnsIterator {
+	return &dnsIterator{
+		Iterator: it,
+		protocol: protocol,
+		url:      url,
+		seen:     make(map[enode.ID]struct{}),
+	}
+}
+
+// Next implements enode.Iterator.
+func (it *dnsIterator) Next() bool {
+	if !it.Iterator.Next() {
+		return false
+	}
+
+	node := it.Iterator.Node()
+
+	it.lock.Lock()
+	if node != nil {
+		it.seen[node.ID()] = struct{}{}
+	}
+	it.yields++
+	it.last = time.Now()
+	it.lock.Unlock()
+
+	return true
+}
+
+// status returns a snapshot of the nodes yielded by the tree.
+func (it *dnsIterator) status() *DNSDiscoveryStatus {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	status := &DNSDiscoveryStatus{
+		Protocol: it.protocol,
+		URL:      it.url,
+		Nodes:    uint64(len(it.seen)),
+		Yields:   it.yields,
+	}
+
+	if !it.last.IsZero() {
+		last := it.last
+		status.LastYield = &last
+	}
+
+	return status
+}
+
+// gatedIterator wraps a dial candidate iterator so it can be paused, withholding
+// any nodes until resumed.
+type gatedIterator struct {
+	enode.Iterator
+
+	lock   sync.Mutex
+	cond   *sync.Cond
+	paused bool
+	closed bool
+}
+
+// newGatedIterator wraps the given iterator, initially unpaused.
+func newGatedIterator(it enode.Iterator) *gatedIterator {
+	gated := &gatedIterator{Iterator: it}
+	gated.cond = sync.NewCond(&gated.lock)
+
+	return gated
+}
+
+// Next implements enode.Iterator, blocking while the iterator is paused. Nodes
+// yielded by the wrapped iterator while paused are dropped.
+func (it *gatedIterator) Next() bool {
+	for {
+		it.lock.Lock()
+		for it.paused && !it.closed {
+			it.cond.Wait()
+		}
+		closed := it.closed
+		it.lock.Unlock()
+
+		if closed || !it.Iterator.Next() {
+			return false
+		}
+
+		if !it.Paused() {
+			return true
+		}
+	}
+}
+
+// Close implements enode.Iterator, releasing any blocked Next call.
+func (it *gatedIterator) Close() {
+	it.lock.Lock()
+	it.closed = true
+	it.cond.Broadcast()
+	it.lock.Unlock()
+
+	it.Iterator.Close()
+}
+
+// SetPaused pauses or resumes the iterator.
+func (it *gatedIterator) SetPaused(paused bool) {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	it.paused = paused
+	it.cond.Broadcast()
+}
+
+// Paused reports whether the iterator is paused.
+func (it *gatedIterator) Paused() bool {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	return it.paused
+}
+
+// newDNSDialCandidates creates a dial candidate iterator mixing the nodes of
+// the given ENR trees, along with the per tree iterators for introspection.
+func newDNSDialCandidates(client *dnsdisc.Client, protocol string, urls []string) (enode.Iterator, []*dnsIterator, error) {
+	var (
+		mix   = enode.NewFairMix(0)
+		trees = make([]*dnsIterator, 0, len(urls))
+	)
+
+	for _, url := range urls {
+		it, err := client.NewIterator(url)
+		if err != nil {
+			mix.Close()
+			return nil, nil, err
+		}
+
+		tree := newDNSIterator(it, protocol, url)
+		mix.AddSource(tree)
+
+		trees = append(trees, tree)
+	}
+
+	return mix, trees, nil
+}
+
+// DNSDiscoveryStatus returns the discovery status of every configured ENR tree.
+func (s *Ethereum) DNSDiscoveryStatus() []*DNSDiscoveryStatus {
+	statuses := make([]*DNSDiscoveryStatus, 0, len(s.dnsTrees))
+	for _, tree := range s.dnsTrees {
+		statuses = append(statuses, tree.status())
+	}
+
+	return statuses
+}
+
+// SetSnapDiscovery pauses (false) or resumes (true) seeking new snap peers via
+// the snap dial candidates, leaving eth peering untouched.
+func (s *Ethereum) SetSnapDiscovery(enabled bool) {
+	s.snapDiscovery.SetPaused(!enabled)
+	log.Info("Snap peer discovery toggled", "enabled", enabled)
+}
+
+// SnapDiscovery reports whether new snap peers are sought via the snap dial
+// candidates.
+func (s *Ethereum) SnapDiscovery() bool {
+	return !s.snapDiscovery.Paused()
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+)
+
+// Tests that a paused gated iterator withholds nodes until resumed or closed.
+func TestGatedIterator(t *testing.T) {
+	t.Parallel()
+
+	node := enode.SignNull(new(enr.Record), enode.ID{1})
+	it := newGatedIterator(enode.CycleNodes([]*enode.Node{node}))
+
+	if !it.Next() {
+		t.Fatalf("unpaused iterator yielded no node")
+	}
+
+	it.SetPaused(true)
+
+	next := make(chan bool, 1)
+	go func() { next <- it.Next() }()
+
+	select {
+	case <-next:
+		t.Fatalf("paused iterator yielded a node")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	it.SetPaused(false)
+
+	select {
+	case ok := <-next:
+		if !ok {
+			t.Fatalf("resumed iterator yielded no node")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("resumed iterator still blocked")
+	}
+
+	it.SetPaused(true)
+	go func() { next <- it.Next() }()
+
+	it.Close()
+
+	select {
+	case ok := <-next:
+		if ok {
+			t.Fatalf("closed iterator yielded a node")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("closed iterator still blocked")
+	}
+}
+
+// Tests that the status of an ENR tree counts distinct nodes separately from
+// the repeated yields of the same nodes.
+func TestDNSIteratorStatus(t *testing.T) {
+	t.Parallel()
+
+	nodes := []*enode.Node{
+		enode.SignNull(new(enr.Record), enode.ID{1}),
+		enode.SignNull(new(enr.Record), enode.ID{2}),
+	}
+	it := newDNSIterator(enode.CycleNodes(nodes), "eth", "enrtree://test")
+	defer it.Close()
+
+	if status := it.status(); status.Nodes != 0 || status.Yields != 0 || status.LastYield != nil {
+		t.Fatalf("initial status mismatch: have %+v", status)
+	}
+
+	before := time.Now()
+
+	for i := 0; i < 5; i++ {
+		if !it.Next() {
+			t.Fatalf("iterator yielded no node")
+		}
+	}
+
+	status := it.status()
+	if status.Nodes != 2 {
+		t.Fatalf("distinct node count mismatch: have %d, want %d", status.Nodes, 2)
+	}
+
+	if status.Yields != 5 {
+		t.Fatalf("yield count mismatch: have %d, want %d", status.Yields, 5)
+	}
+
+	if status.LastYield == nil || status.LastYield.Before(before) {
+		t.Fatalf("last yield time mismatch: have %v, want after %v", status.LastYield, before)
+	}
+
+	if status.Protocol != "eth" || status.URL != "enrtree://test" {
+		t.Fatalf("tree mismatch: have %s %s", status.Protocol, status.URL)
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -152,6 +153,8 @@ type Downloader struct {
 
 	ethereum.ChainValidator
 
+	ignoreCheckpoints atomic.Bool // Whether peers are synced without enforcing whitelisted checkpoints (incident response)
+
 	// Testing hooks
 	syncInitHook     func(uint64, uint64)  // Method to call upon initiating a new sync run
 	bodyFetchHook    func([]*types.Header) // Method to call upon starting a block body fetch
@@ -377,6 +380,11 @@ func (d *Downloader) LegacySync(id string, head common.Hash, td, ttd *big.Int, m
 			log.Warn("Downloader wants to drop peer, but peerdrop-function is not set", "peer", id)
 		} else {
 			d.dropPeer(id)
+
+			if errors.Is(err, whitelist.ErrCheckpointMismatch) {
+				whitelistDropCounter.Inc(1)
+				whitelistSyncDropCounter.Inc(1)
+			}
 		}
 
 		return err
@@ -883,6 +891,26 @@ func calculateRequestSpan(remoteHeight, localHeight uint64) (int64, int, int, ui
 }
 
 // curried fetchHeadersByNumber
+// SetCheckpointEnforcement toggles whether peers are validated against the
+// whitelisted checkpoints before syncing from them. It's enabled by default and
+// only meant to be turned off temporarily, e.g. to catch up with the canonical
+// chain after a bad checkpoint got whitelisted.
+func (d *Downloader) SetCheckpointEnforcement(enabled bool) {
+	d.ignoreCheckpoints.Store(!enabled)
+
+	if enabled {
+		log.Info("Enabled checkpoint enforcement in the downloader")
+	} else {
+		log.Warn("Disabled checkpoint enforcement in the downloader")
+	}
+}
+
+// CheckpointEnforcement reports whether peers are validated against the
+// whitelisted checkpoints before syncing from them.
+func (d *Downloader) CheckpointEnforcement() bool {
+	return !d.ignoreCheckpoints.Load()
+}
+
 func (d *Downloader) getFetchHeadersByNumber(p *peerConnection) func(number uint64, amount int, skip int, reverse bool) ([]*types.Header, []common.Hash, error) {
 	return func(number uint64, amount int, skip int, reverse bool) ([]*types.Header, []common.Hash, error) {
 		return d.fetchHeadersByNumber(p, number, amount, skip, reverse)
@@ -896,7 +924,7 @@ func (d *Downloader) getFetchHeadersByNumber(p *peerConnection) func(number uint
 // the head links match), we do a binary search to find the common ancestor.
 func (d *Downloader) findAncestor(p *peerConnection, remoteHeader *types.Header) (uint64, error) {
 	// Check the validity of peer from which the chain is to be downloaded
-	if d.ChainValidator != nil {
+	if d.ChainValidator != nil && d.CheckpointEnforcement() {
 		if _, err := d.IsValidPeer(remoteHeader, d.getFetchHeadersByNumber(p)); err != nil {
 			return 0, err
 		}
@@ -1734,6 +1762,11 @@ func (d *Downloader) importBlockResults(results []*fetchResult) error {
 			log.Debug("Downloaded item processing failed on sidechain import", "index", index, "err", err)
 		}
 
+		// A paused import is no fault of the peer, retry once it resumes
+		if errors.Is(err, core.ErrInsertPaused) {
+			return err
+		}
+
 		// If we've received too long future chain error (from whitelisting service),
 		// return that as the root error and `errInvalidChain` as context.
 		if errors.Is(err, whitelist.ErrLongFutureChain) {
@@ -2011,6 +2011,37 @@ func TestFakedSyncProgress66WhitelistMismatch(t *testing.T) {
 	}
 }
 
+// TestFakedSyncProgress66WhitelistMismatchNotEnforced tests that a whitelisted
+// checkpoint mismatch doesn't fail the sync if checkpoint enforcement is off.
+func TestFakedSyncProgress66WhitelistMismatchNotEnforced(t *testing.T) {
+	t.Parallel()
+
+	protocol := uint(eth.ETH66)
+	mode := FullSync
+
+	tester := newTester(t)
+	validate := func(count int) (bool, error) {
+		return false, whitelist.ErrCheckpointMismatch
+	}
+	tester.downloader.ChainValidator = newWhitelistFake(validate)
+
+	defer tester.terminate()
+
+	if !tester.downloader.CheckpointEnforcement() {
+		t.Fatal("checkpoint enforcement disabled by default")
+	}
+
+	tester.downloader.SetCheckpointEnforcement(false)
+
+	chainA := testChainForkLightA.blocks
+	tester.newPeer("light", protocol, chainA[1:])
+
+	// Synchronise with the peer and make sure the mismatch was ignored
+	if err := tester.sync("light", nil, mode); err != nil {
+		t.Fatalf("failed to synchronise blocks: %v", err)
+	}
+}
+
 // TestFakedSyncProgress66WhitelistMatch tests if in case of whitelisted
 // checkpoint match with opposite peer, the sync should succeed.
 func TestFakedSyncProgress66WhitelistMatch(t *testing.T) {
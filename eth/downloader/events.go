@@ -23,3 +23,10 @@ type DoneEvent struct {
 }
 type StartEvent struct{}
 type FailedEvent struct{ Err error }
+
+// SyncModeChangeEvent is posted when the chain syncer switches sync modes.
+type SyncModeChangeEvent struct {
+	Old  SyncMode
+	New  SyncMode
+	Head uint64 // Number of the local head block at the time of the transition
+}
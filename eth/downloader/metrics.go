@@ -39,4 +39,15 @@ var (
 	receiptTimeoutMeter = metrics.NewRegisteredMeter("eth/downloader/receipts/timeout", nil)
 
 	throttleCounter = metrics.NewRegisteredCounter("eth/downloader/throttle", nil)
+
+	whitelistDropCounter         = metrics.NewRegisteredCounter("eth/downloader/whitelist/drop", nil)          // Peers dropped for conflicting with a whitelisted or required block, for any reason
+	whitelistSyncDropCounter     = metrics.NewRegisteredCounter("eth/downloader/whitelist/drop/sync", nil)     // Peers dropped for a chain conflicting with a whitelisted checkpoint while syncing
+	whitelistRequiredDropCounter = metrics.NewRegisteredCounter("eth/downloader/whitelist/drop/required", nil) // Peers dropped for a mismatching required block on connection
 )
+
+// MarkRequiredBlockDrop counts a peer dropped on connection for serving a block
+// conflicting with a required one, e.g. the latest whitelisted checkpoint.
+func MarkRequiredBlockDrop() {
+	whitelistDropCounter.Inc(1)
+	whitelistRequiredDropCounter.Inc(1)
+}
@@ -165,13 +165,18 @@ func (w *Service) ProcessCheckpoint(endBlockNum uint64, endBlockHash common.Hash
 	}
 }
 
-// GetCheckpointWhitelist returns the existing whitelisted
+// GetCheckpointWhitelist returns a copy of the existing whitelisted
 // entries of checkpoint of the form block number -> block hash.
 func (w *Service) GetCheckpointWhitelist() map[uint64]common.Hash {
 	w.m.Lock()
 	defer w.m.Unlock()
 
-	return w.checkpointWhitelist
+	whitelist := make(map[uint64]common.Hash, len(w.checkpointWhitelist))
+	for number, hash := range w.checkpointWhitelist {
+		whitelist[number] = hash
+	}
+
+	return whitelist
 }
 
 // PurgeCheckpointWhitelist purges data from checkpoint whitelist map
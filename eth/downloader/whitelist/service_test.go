@@ -38,6 +38,11 @@ func TestWhitelistCheckpoint(t *testing.T) {
 	s.enqueueCheckpointWhitelist(11, common.Hash{})
 	s.dequeueCheckpointWhitelist()
 	require.Equal(t, s.length(), 10, "expected 10 items in whitelist")
+
+	// The returned whitelist is a copy, safe to use without the lock
+	whitelist := s.GetCheckpointWhitelist()
+	delete(whitelist, 11)
+	require.Len(t, s.GetCheckpointWhitelist(), 10, "expected 10 items in whitelist")
 }
 
 // TestIsValidPeer checks the IsValidPeer function in isolation
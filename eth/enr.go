@@ -0,0 +1,68 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// ENRForkID is the fork identifier (EIP-2124) advertised in the node record.
+type ENRForkID struct {
+	Hash hexutil.Bytes  `json:"hash"` // CRC32 checksum of the genesis hash and passed forks
+	Next hexutil.Uint64 `json:"next"` // Block number or timestamp of the next upcoming fork, 0 if none
+}
+
+// NodeENR describes the record the local node advertises on the discovery
+// network along with the status of the discovery protocols.
+type NodeENR struct {
+	ENR         string     `json:"enr"`
+	ID          string     `json:"id"`
+	Seq         uint64     `json:"seq"`
+	ForkID      *ENRForkID `json:"forkId"` // Nil if the record has no `eth` entry
+	DiscoveryV4 bool       `json:"discoveryV4"`
+	DiscoveryV5 bool       `json:"discoveryV5"`
+}
+
+// newNodeENR decodes the fork identifier of the given node record, which is
+// kept in sync with the chain by the ENR updater.
+func newNodeENR(node *enode.Node) *NodeENR {
+	info := &NodeENR{
+		ENR: node.String(),
+		ID:  node.ID().String(),
+		Seq: node.Seq(),
+	}
+
+	if id, err := eth.LoadForkID(node.Record()); err == nil {
+		info.ForkID = &ENRForkID{
+			Hash: id.Hash[:],
+			Next: hexutil.Uint64(id.Next),
+		}
+	}
+
+	return info
+}
+
+// NodeENR returns the current record of the local node and its fork identifier.
+func (s *Ethereum) NodeENR() *NodeENR {
+	info := newNodeENR(s.p2pServer.LocalNode().Node())
+	info.DiscoveryV4 = !s.p2pServer.NoDiscovery
+	info.DiscoveryV5 = s.p2pServer.DiscoveryV5
+
+	return info
+}
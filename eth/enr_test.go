@@ -0,0 +1,71 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/forkid"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// testENREntry mirrors the `eth` entry maintained by the ENR updater.
+type testENREntry struct {
+	ForkID forkid.ID
+}
+
+func (e testENREntry) ENRKey() string { return "eth" }
+
+// Tests that the fork identifier is decoded from the local node record.
+func TestNodeENR(t *testing.T) {
+	t.Parallel()
+
+	db, err := enode.OpenDB("")
+	if err != nil {
+		t.Fatalf("failed to open node database: %v", err)
+	}
+	defer db.Close()
+
+	key, _ := crypto.GenerateKey()
+	ln := enode.NewLocalNode(db, key)
+
+	if info := newNodeENR(ln.Node()); info.ForkID != nil {
+		t.Fatalf("fork id mismatch: have %v, want nil", info.ForkID)
+	}
+
+	id := forkid.ID{Hash: [4]byte{0xde, 0xad, 0xbe, 0xef}, Next: 1234}
+	ln.Set(testENREntry{ForkID: id})
+
+	info := newNodeENR(ln.Node())
+	if info.ForkID == nil {
+		t.Fatalf("fork id missing from the record")
+	}
+
+	if !bytes.Equal(info.ForkID.Hash, id.Hash[:]) {
+		t.Fatalf("fork hash mismatch: have %x, want %x", info.ForkID.Hash, id.Hash)
+	}
+
+	if uint64(info.ForkID.Next) != id.Next {
+		t.Fatalf("fork next mismatch: have %d, want %d", info.ForkID.Next, id.Next)
+	}
+
+	if info.ENR != ln.Node().String() || info.Seq != ln.Node().Seq() {
+		t.Fatalf("record mismatch: have %s (seq %d), want %s (seq %d)", info.ENR, info.Seq, ln.Node().String(), ln.Node().Seq())
+	}
+}
@@ -90,6 +90,8 @@ var Defaults = Config{
 	TrieDirtyCache:          256,
 	TrieTimeout:             60 * time.Minute,
 	SnapshotCache:           102,
+	TriesInMemory:           DefaultTriesInMemory,
+	NoPruningSnapshotShare:  DefaultNoPruningSnapshotShare,
 	FilterLogCacheSize:      32,
 	Miner:                   miner.DefaultConfig,
 	TxPool:                  txpool.DefaultConfig,
@@ -122,6 +124,26 @@ func init() {
 	}
 }
 
+const (
+	// DefaultTriesInMemory is the number of recent block states kept in memory
+	// if unset. It spans several bor sprints, so the reorgs common around sprint
+	// boundaries can be handled without regenerating state from disk.
+	DefaultTriesInMemory = 128
+
+	// MinTriesInMemory is the lowest number of recent block states kept in
+	// memory, covering a full 64 block sprint of the pre-Delhi bor networks.
+	MinTriesInMemory = 64
+
+	// MinSnapSyncSnapshotCache is the snapshot cache size in megabytes enabled
+	// if snap sync is requested without one.
+	MinSnapSyncSnapshotCache = 16
+
+	// DefaultNoPruningSnapshotShare is the percentage of the dirty trie cache
+	// moved to the snapshot cache of an archive node if unset, the rest going to
+	// the clean trie cache.
+	DefaultNoPruningSnapshotShare = 40
+)
+
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
 
 // Config contains configuration options for of the ETH and LES protocols.
@@ -130,6 +152,10 @@ type Config struct {
 	// If nil, the Ethereum main net block is used.
 	Genesis *core.Genesis `toml:",omitempty"`
 
+	// RequireGenesis rejects a nil Genesis on an empty database, instead of
+	// implicitly using the Ethereum main net block.
+	RequireGenesis bool `toml:",omitempty"`
+
 	// Protocol options
 	NetworkId uint64 // Network ID to use for selecting peers to connect to
 	SyncMode  downloader.SyncMode
@@ -142,6 +168,18 @@ type Config struct {
 	NoPruning  bool // Whether to disable pruning and flush everything to disk
 	NoPrefetch bool // Whether to disable prefetching and only load state on demand
 
+	// Maximum number of canonical blocks a reorg may drop before the node refuses
+	// it and waits for manual intervention (0 = unlimited)
+	MaxReorgDepth uint64 `toml:",omitempty"`
+
+	// Record per block execution time, transaction count and gas used histograms,
+	// split by the serial or parallel processor in use
+	BlockExecutionMetrics bool `toml:",omitempty"`
+
+	// Fail the startup instead of just logging the error if an interrupted
+	// state pruning can't be recovered, as the state may be corrupt
+	FailOnPruningRecoveryError bool `toml:",omitempty"`
+
 	TxLookupLimit uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
 
 	// RequiredBlocks is a set of block number -> hash mappings which must be in the
@@ -149,6 +187,13 @@ type Config struct {
 	// presence of these blocks for every new peer connection.
 	RequiredBlocks map[uint64]common.Hash `toml:"-"`
 
+	// RequiredBlocksURL is an endpoint serving additional required blocks signed
+	// by RequiredBlocksSigner, refreshed every RequiredBlocksRefreshInterval
+	// (empty = disabled)
+	RequiredBlocksURL             string         `toml:",omitempty"`
+	RequiredBlocksSigner          common.Address `toml:",omitempty"`
+	RequiredBlocksRefreshInterval time.Duration  `toml:",omitempty"`
+
 	// Light client options
 	LightServ          int  `toml:",omitempty"` // Maximum percentage of time allowed for serving LES requests
 	LightIngress       int  `toml:",omitempty"` // Incoming bandwidth limit for light servers
@@ -169,6 +214,10 @@ type Config struct {
 	DatabaseCache      int
 	DatabaseFreezer    string
 
+	// Open a database written by a newer version read-only instead of failing,
+	// allowing it to be inspected or exported
+	DatabaseReadOnlyIfNewer bool `toml:",omitempty"`
+
 	TrieCleanCache          int
 	TrieCleanCacheJournal   string        `toml:",omitempty"` // Disk journal directory for trie cache to survive node restarts
 	TrieCleanCacheRejournal time.Duration `toml:",omitempty"` // Time interval to regenerate the journal for clean cache
@@ -178,21 +227,59 @@ type Config struct {
 	Preimages               bool
 	TriesInMemory           uint64
 
+	// Fail startup if snap sync is requested without a snapshot cache, instead
+	// of enabling a minimal one
+	SnapSyncStrict bool `toml:",omitempty"`
+
+	// Warm the trie clean cache with the state touched by the latest block once
+	// the first sync cycle completes
+	WarmStateAfterSync bool `toml:",omitempty"`
+
+	// Archive node cache options, as the dirty trie cache is redistributed when
+	// pruning is disabled
+	NoPruningSnapshotShare int `toml:",omitempty"` // Percentage (0-100) of the dirty cache moved to the snapshot cache, the rest goes to the clean cache (0 = DefaultNoPruningSnapshotShare)
+	TrieCleanCacheMax      int `toml:",omitempty"` // Maximum size of the clean trie cache in megabytes, startup fails if exceeded (0 = unlimited)
+
 	// This is the number of blocks for which logs will be cached in the filter system.
 	FilterLogCacheSize int
 
+	// MinFreeDiskSpace is the free disk space in megabytes of the chain database
+	// below which block import and mining are paused (0 = disabled).
+	MinFreeDiskSpace uint64 `toml:",omitempty"`
+
+	// FilterUnindexedLimit is the maximum number of blocks not yet covered by the
+	// bloom indexer a log query may span, wider ones are rejected (0 = unlimited).
+	FilterUnindexedLimit uint64 `toml:",omitempty"`
+
+	// Bloom bit retrieval options, zero values fall back to the built-in defaults
+	BloomServiceThreads int `toml:",omitempty"` // Number of goroutines servicing bloom bit retrievals for all filters
+	BloomServiceQueue   int `toml:",omitempty"` // Number of pending bloom bit retrievals before new ones are rejected as busy
+
 	// Mining options
 	Miner miner.Config
 
+	// Endpoint of a remote signer (clef) authorizing the consensus engine to
+	// seal blocks, bypassing the local keystore lookup
+	RemoteSigner string `toml:",omitempty"`
+
 	// Ethash options
 	Ethash ethash.Config
 
 	// Transaction pool options
 	TxPool txpool.Config
 
+	// TxFilter optionally rejects transactions before they enter the txpool,
+	// e.g. for compliance driven deployments (nil = accept all)
+	TxFilter txpool.TxFilter `toml:"-"`
+
 	// Gas Price Oracle options
 	GPO gasprice.Config
 
+	// Derive the txpool minimum gas price from the given percentile of recent
+	// block tips, updated every TxPoolFloorInterval (0 = disabled)
+	TxPoolFloorPercentile int           `toml:",omitempty"`
+	TxPoolFloorInterval   time.Duration `toml:",omitempty"`
+
 	// Enables tracking of SHA3 preimages in the VM
 	EnablePreimageRecording bool
 
@@ -212,6 +299,21 @@ type Config struct {
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
 
+	// RPCNamespaces limits the APIs registered by the backend to the listed
+	// namespaces, regardless of the modules exposed by the node (nil = all).
+	RPCNamespaces []string `toml:",omitempty"`
+
+	// MaxConcurrentTraces is the maximum number of debug_trace* requests served
+	// at the same time, further ones are rejected (0 = unlimited).
+	MaxConcurrentTraces int `toml:",omitempty"`
+
+	// DisableBorFilterAPI skips registering the bor aware eth filter API.
+	DisableBorFilterAPI bool `toml:",omitempty"`
+
+	// AdvertisedNetworkID is the network ID reported by net_version instead of
+	// NetworkId, which is still used for peering (0 = NetworkId).
+	AdvertisedNetworkID uint64 `toml:",omitempty"`
+
 	// Checkpoint is a hardcoded checkpoint which can be nil.
 	Checkpoint *params.TrustedCheckpoint `toml:",omitempty"`
 
@@ -225,9 +327,47 @@ type Config struct {
 	// URL to connect to Heimdall node
 	HeimdallURL string
 
+	// Additional Heimdall URLs the checkpoint whitelist service fails over to
+	// when HeimdallURL is unreachable
+	HeimdallFailoverURLs []string `toml:",omitempty"`
+
+	// Period after startup during which whitelisted checkpoints are fetched
+	// and logged but not enforced on peers
+	WhitelistGracePeriod time.Duration `toml:",omitempty"`
+
+	// Timeout of the first, blocking checkpoint whitelisting at startup, which
+	// defaults to the one of the periodic runs if unset
+	WhitelistFirstTimeout time.Duration `toml:",omitempty"`
+
+	// Hold back the startup, and so serving RPC if the node opens its endpoints
+	// after the services, until the first checkpoint got whitelisted or this
+	// timeout elapsed (0 = disabled)
+	WhitelistRPCBarrier time.Duration `toml:",omitempty"`
+
+	// Pause mining while no checkpoint has been whitelisted for longer than
+	// this, as the node may be following a fork (0 = disabled)
+	WhitelistStalenessLimit time.Duration `toml:",omitempty"`
+
+	// Number of checkpoints fetched and verified concurrently when catching up
+	// the checkpoint whitelist (0 or 1 = serially)
+	WhitelistVerifyWorkers int `toml:",omitempty"`
+
+	// Number of recently rejected heimdall checkpoints kept along with the
+	// reason, for post-incident analysis (0 = none)
+	WhitelistRejectionsLimit int `toml:",omitempty"`
+
+	// Persist the latest whitelisted checkpoint to the database, restoring it
+	// at startup before heimdall is reached
+	PersistWhitelist bool `toml:",omitempty"`
+
 	// No heimdall service
 	WithoutHeimdall bool
 
+	// Cross-check the genesis chain config against the parameters reported by
+	// heimdall on startup, failing if VerifyChainConfigStrict is set
+	VerifyChainConfigWithHeimdall bool
+	VerifyChainConfigStrict       bool
+
 	// Address to connect to Heimdall gRPC server
 	HeimdallgRPCAddress string
 
@@ -243,6 +383,9 @@ type Config struct {
 	// Bor logs flag
 	BorLogs bool
 
+	// Observer runs the node as a read-only replica, without a miner
+	Observer bool `toml:",omitempty"`
+
 	// Parallel EVM (Block-STM) related config
 	ParallelEVM core.ParallelEVMConfig `toml:",omitempty"`
 
@@ -0,0 +1,40 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PreserveDecisionEvent is posted on the event mux whenever the blockchain asks
+// whether a block should be preserved during a reorg because it's a local one.
+// It's delivered synchronously from within the reorg, so subscribers must not
+// block on chain operations while handling it.
+type PreserveDecisionEvent struct {
+	Header    *types.Header
+	Preserved bool
+}
+
+// CheckpointWhitelistEvent is posted on the event mux and sent to the checkpoint
+// whitelist subscribers whenever new bor checkpoints are whitelisted. Number and
+// Hash identify the end block of the latest one.
+type CheckpointWhitelistEvent struct {
+	Number uint64
+	Hash   common.Hash
+	Count  int // Number of checkpoints whitelisted in the update
+}
@@ -274,7 +274,7 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 		for {
 			select {
 			case logs := <-matchedLogs:
-				for _, log := range logs {
+				for _, log := range api.filterBorLogs(logs) {
 					log := log
 					notifier.Notify(rpcSub.ID, &log)
 				}
@@ -324,7 +324,7 @@ func (api *FilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
 			case l := <-logs:
 				api.filtersMu.Lock()
 				if f, found := api.filters[logsSub.ID]; found {
-					f.logs = append(f.logs, l...)
+					f.logs = append(f.logs, api.filterBorLogs(l)...)
 				}
 				api.filtersMu.Unlock()
 			case <-logsSub.Err():
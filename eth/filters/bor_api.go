@@ -16,6 +16,24 @@ func (api *FilterAPI) SetChainConfig(chainConfig *params.ChainConfig) {
 	api.chainConfig = chainConfig
 }
 
+// filterBorLogs drops the state-sync logs from the given logs unless bor logs
+// are enabled, keeping subscriptions consistent with eth_getLogs.
+func (api *FilterAPI) filterBorLogs(logs []*types.Log) []*types.Log {
+	if api.borLogs {
+		return logs
+	}
+
+	filtered := make([]*types.Log, 0, len(logs))
+
+	for _, log := range logs {
+		if log.TxHash != types.GetDerivedBorTxHash(types.BorReceiptKey(log.BlockNumber, log.BlockHash)) {
+			filtered = append(filtered, log)
+		}
+	}
+
+	return filtered
+}
+
 func (api *FilterAPI) GetBorBlockLogs(ctx context.Context, crit FilterCriteria) ([]*types.Log, error) {
 	if api.chainConfig == nil {
 		return nil, errors.New("no chain config found. Proper PublicFilterAPI initialization required")
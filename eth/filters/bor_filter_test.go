@@ -156,3 +156,26 @@ func TestBorFilters(t *testing.T) {
 		t.Error("expected 0 log, got", len(logs))
 	}
 }
+
+func TestFilterBorLogs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		blockHash = common.HexToHash("0x1")
+		txLog     = &types.Log{BlockNumber: 16, BlockHash: blockHash, TxHash: common.HexToHash("0x2")}
+		syncLog   = &types.Log{BlockNumber: 16, BlockHash: blockHash}
+		logs      = []*types.Log{txLog, syncLog}
+	)
+
+	types.DeriveFieldsForBorLogs([]*types.Log{syncLog}, blockHash, 16, 1, 1)
+
+	enabled := &FilterAPI{borLogs: true}
+	if have := enabled.filterBorLogs(logs); len(have) != 2 {
+		t.Fatalf("bor logs enabled: have %d logs, want 2", len(have))
+	}
+
+	disabled := &FilterAPI{borLogs: false}
+	if have := disabled.filterBorLogs(logs); len(have) != 1 || have[0] != txLog {
+		t.Fatalf("bor logs disabled: have %v, want only the transaction log", have)
+	}
+}
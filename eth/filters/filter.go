@@ -174,6 +174,12 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 		size, sections = f.sys.backend.BloomStatus()
 	)
 
+	if f.begin >= 0 {
+		if err := checkUnindexedRange(uint64(f.begin), end, sections*size, f.sys.cfg.UnindexedLimit); err != nil {
+			return nil, err
+		}
+	}
+
 	if indexed := sections * size; indexed > uint64(f.begin) {
 		if indexed > end {
 			logs, err = f.indexedLogs(ctx, end)
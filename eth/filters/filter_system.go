@@ -41,8 +41,9 @@ import (
 
 // Config represents the configuration of the filter system.
 type Config struct {
-	LogCacheSize int           // maximum number of cached blocks (default: 32)
-	Timeout      time.Duration // how long filters stay active (default: 5min)
+	LogCacheSize   int           // maximum number of cached blocks (default: 32)
+	Timeout        time.Duration // how long filters stay active (default: 5min)
+	UnindexedLimit uint64        // maximum number of unindexed blocks a log query may span (default: unlimited)
 }
 
 func (cfg Config) withDefaults() Config {
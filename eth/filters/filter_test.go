@@ -18,6 +18,7 @@ package filters
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
@@ -251,3 +252,62 @@ func TestFilters(t *testing.T) {
 		}
 	}
 }
+
+// Tests that log queries spanning too many unindexed blocks are rejected with
+// a suggested narrower range.
+func TestFilterUnindexedLimit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db                = rawdb.NewMemoryDatabase()
+		backend, sys      = newTestFilterSystem(t, db, Config{UnindexedLimit: 100})
+		gspec             = &core.Genesis{Config: params.TestChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+		_, chain, _       = core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), int(params.BloomBitsBlocks)+200, func(int, *core.BlockGen) {})
+		indexed           = params.BloomBitsBlocks
+		head              = uint64(len(chain))
+		errUnindexedRange *UnindexedRangeError
+	)
+
+	gspec.MustCommit(db)
+
+	for _, block := range chain {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+	}
+
+	backend.sections = 1
+
+	for i, tc := range []struct {
+		begin, end int64
+		rejected   bool
+		suggested  uint64
+	}{
+		{int64(indexed), int64(indexed) + 99, false, 0},
+		{int64(head) - 99, -1, false, 0},
+		{0, -1, true, indexed + 99},
+		{int64(indexed) + 50, -1, true, indexed + 149},
+	} {
+		_, err := sys.NewRangeFilter(tc.begin, tc.end, nil, nil).Logs(context.Background())
+		if !tc.rejected {
+			if err != nil {
+				t.Fatalf("test %d: unexpected error: %v", i, err)
+			}
+
+			continue
+		}
+
+		if !errors.As(err, &errUnindexedRange) {
+			t.Fatalf("test %d: error mismatch: have %v, want %T", i, err, errUnindexedRange)
+		}
+
+		data := errUnindexedRange.ErrorData().(*UnindexedRangeData)
+		if uint64(data.SuggestedTo) != tc.suggested {
+			t.Fatalf("test %d: suggested range end mismatch: have %d, want %d", i, data.SuggestedTo, tc.suggested)
+		}
+
+		if data.IndexedTo == nil || uint64(*data.IndexedTo) != indexed-1 {
+			t.Fatalf("test %d: indexed boundary mismatch: have %v, want %d", i, data.IndexedTo, indexed-1)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UnindexedRangeError is returned by log queries spanning more blocks not yet
+// covered by the bloom indexer than allowed. Since the unindexed blocks at the
+// head of the chain are filtered one by one, such queries are rejected with a
+// suggested narrower range instead.
+type UnindexedRangeError struct {
+	From    uint64 // First block of the requested range
+	To      uint64 // Last block of the requested range
+	Indexed uint64 // Number of blocks covered by the bloom indexer
+	Limit   uint64 // Maximum number of unindexed blocks a query may span
+}
+
+// UnindexedRangeData is the structured data attached to an UnindexedRangeError
+// in RPC responses.
+type UnindexedRangeData struct {
+	IndexedTo     *hexutil.Uint64 `json:"indexedTo"`
+	SuggestedFrom hexutil.Uint64  `json:"suggestedFrom"`
+	SuggestedTo   hexutil.Uint64  `json:"suggestedTo"`
+}
+
+func (e *UnindexedRangeError) Error() string {
+	return fmt.Sprintf("block range %d-%d not fully indexed, at most %d unindexed blocks allowed", e.From, e.To, e.Limit)
+}
+
+// ErrorCode returns the JSON error code for a rejected range, matching the one
+// commonly used for exceeded query limits.
+func (e *UnindexedRangeError) ErrorCode() int {
+	return -32005
+}
+
+// ErrorData returns the indexed boundary and the narrower range suggested.
+func (e *UnindexedRangeError) ErrorData() interface{} {
+	data := &UnindexedRangeData{
+		SuggestedFrom: hexutil.Uint64(e.From),
+		SuggestedTo:   hexutil.Uint64(e.suggestedTo()),
+	}
+	if e.Indexed > 0 {
+		indexedTo := hexutil.Uint64(e.Indexed - 1)
+		data.IndexedTo = &indexedTo
+	}
+
+	return data
+}
+
+// suggestedTo returns the last block of the requested range a query starting at
+// the same block may ask for without exceeding the limit.
+func (e *UnindexedRangeError) suggestedTo() uint64 {
+	first := e.From
+	if e.Indexed > first {
+		first = e.Indexed
+	}
+
+	return first + e.Limit - 1
+}
+
+// checkUnindexedRange returns an UnindexedRangeError if the range [begin, end]
+// spans more than limit blocks beyond the indexed ones. A zero limit disables
+// the check.
+func checkUnindexedRange(begin, end, indexed, limit uint64) error {
+	if limit == 0 || begin > end {
+		return nil
+	}
+
+	first := begin
+	if indexed > first {
+		first = indexed
+	}
+
+	if first > end || end-first+1 <= limit {
+		return nil
+	}
+
+	return &UnindexedRangeError{From: begin, To: end, Indexed: indexed, Limit: limit}
+}
@@ -113,8 +113,28 @@ func (oracle *Oracle) processBlock(bf *blockFees, percentiles []float64) {
 		return
 	}
 
+	var (
+		sorter  = make(sortGasAndReward, 0, len(bf.block.Transactions()))
+		gasUsed = bf.block.GasUsed()
+	)
+
+	for i, tx := range bf.block.Transactions() {
+		// State-sync transactions carry no gas price and would drag the
+		// percentiles down, leave them out if requested
+		if oracle.excludeSystemTxs && isBorSystemTx(tx) {
+			if used := bf.receipts[i].GasUsed; used <= gasUsed {
+				gasUsed -= used
+			}
+
+			continue
+		}
+
+		reward, _ := tx.EffectiveGasTip(bf.block.BaseFee())
+		sorter = append(sorter, txGasAndReward{gasUsed: bf.receipts[i].GasUsed, reward: reward})
+	}
+
 	bf.results.reward = make([]*big.Int, len(percentiles))
-	if len(bf.block.Transactions()) == 0 {
+	if len(sorter) == 0 {
 		// return an all zero row if there are no transactions to gather data from
 		for i := range bf.results.reward {
 			bf.results.reward[i] = new(big.Int)
@@ -123,13 +143,6 @@ func (oracle *Oracle) processBlock(bf *blockFees, percentiles []float64) {
 		return
 	}
 
-	sorter := make(sortGasAndReward, len(bf.block.Transactions()))
-
-	for i, tx := range bf.block.Transactions() {
-		reward, _ := tx.EffectiveGasTip(bf.block.BaseFee())
-		sorter[i] = txGasAndReward{gasUsed: bf.receipts[i].GasUsed, reward: reward}
-	}
-
 	sort.Stable(sorter)
 
 	var txIndex int
@@ -137,8 +150,8 @@ func (oracle *Oracle) processBlock(bf *blockFees, percentiles []float64) {
 	sumGasUsed := sorter[0].gasUsed
 
 	for i, p := range percentiles {
-		thresholdGasUsed := uint64(float64(bf.block.GasUsed()) * p / 100)
-		for sumGasUsed < thresholdGasUsed && txIndex < len(bf.block.Transactions())-1 {
+		thresholdGasUsed := uint64(float64(gasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(sorter)-1 {
 			txIndex++
 			sumGasUsed += sorter[txIndex].gasUsed
 		}
@@ -147,6 +160,14 @@ func (oracle *Oracle) processBlock(bf *blockFees, percentiles []float64) {
 	}
 }
 
+// isBorSystemTx reports whether the transaction is a bor state-sync transaction,
+// which is executed by the protocol on behalf of no sender and pays no gas.
+func isBorSystemTx(tx *types.Transaction) bool {
+	to := tx.To()
+
+	return to != nil && *to == (common.Address{}) && tx.Gas() == 0 && tx.GasPrice().Sign() == 0
+}
+
 // resolveBlockRange resolves the specified block range to absolute block numbers while also
 // enforcing backend specific limitations. The pending block and corresponding receipts are
 // also returned if requested and available.
@@ -22,6 +22,8 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -97,3 +99,66 @@ func TestFeeHistory(t *testing.T) {
 		}
 	}
 }
+
+func TestFeeHistoryExcludeSystemTxs(t *testing.T) {
+	t.Parallel()
+
+	backend := newTestBackend(t, big.NewInt(0), false)
+	defer backend.teardown()
+
+	var (
+		baseFee = big.NewInt(params.GWei)
+		txs     = types.Transactions{types.NewBorTransaction()}
+		// State-sync receipts account for a large share of the block gas
+		receipts = types.Receipts{{GasUsed: 63000}}
+		gasUsed  = uint64(63000)
+	)
+
+	for tip := int64(1); tip <= 3; tip++ {
+		txs = append(txs, types.NewTx(&types.DynamicFeeTx{
+			GasTipCap: big.NewInt(tip * params.GWei),
+			GasFeeCap: big.NewInt(10 * params.GWei),
+			Gas:       21000,
+		}))
+		receipts = append(receipts, &types.Receipt{GasUsed: 21000})
+		gasUsed += 21000
+	}
+
+	header := &types.Header{Number: big.NewInt(1), GasLimit: 1000000, GasUsed: gasUsed, BaseFee: baseFee}
+	block := types.NewBlockWithHeader(header).WithBody(txs, nil)
+	percentiles := []float64{0, 50, 100}
+
+	testCases := []struct {
+		name     string
+		exclude  bool
+		expected []*big.Int
+	}{
+		{
+			name:     "system transactions included",
+			exclude:  false,
+			expected: []*big.Int{big.NewInt(-params.GWei), big.NewInt(-params.GWei), big.NewInt(3 * params.GWei)},
+		},
+		{
+			name:     "system transactions excluded",
+			exclude:  true,
+			expected: []*big.Int{big.NewInt(params.GWei), big.NewInt(2 * params.GWei), big.NewInt(3 * params.GWei)},
+		},
+	}
+
+	for _, tc := range testCases {
+		oracle := NewOracle(backend, Config{ExcludeSystemTxs: tc.exclude})
+
+		fees := &blockFees{blockNumber: 1, header: header, block: block, receipts: receipts}
+		oracle.processBlock(fees, percentiles)
+
+		if len(fees.results.reward) != len(tc.expected) {
+			t.Fatalf("%s: reward count mismatch: have %d, want %d", tc.name, len(fees.results.reward), len(tc.expected))
+		}
+
+		for i, reward := range fees.results.reward {
+			if reward.Cmp(tc.expected[i]) != 0 {
+				t.Fatalf("%s: percentile %v reward mismatch: have %v, want %v", tc.name, percentiles[i], reward, tc.expected[i])
+			}
+		}
+	}
+}
@@ -18,6 +18,8 @@ package gasprice
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
 	"sort"
 	"sync"
@@ -39,6 +41,10 @@ var (
 	DefaultIgnorePrice = big.NewInt(2 * params.Wei)
 )
 
+// errInvalidOracleConfig is returned when reconfiguring the oracle with sampling
+// parameters out of their valid ranges.
+var errInvalidOracleConfig = errors.New("invalid gas price oracle config")
+
 type Config struct {
 	Blocks           int
 	Percentile       int
@@ -47,6 +53,14 @@ type Config struct {
 	Default          *big.Int `toml:",omitempty"`
 	MaxPrice         *big.Int `toml:",omitempty"`
 	IgnorePrice      *big.Int `toml:",omitempty"`
+
+	// ExcludeSystemTxs leaves bor state-sync transactions out of the fee
+	// history reward percentiles
+	ExcludeSystemTxs bool `toml:",omitempty"`
+
+	// IgnoreSenders are left out of the sampled transaction prices, e.g. bor
+	// system addresses submitting non-standard gas price transactions
+	IgnoreSenders []common.Address `toml:",omitempty"`
 }
 
 // OracleBackend includes all necessary background APIs for oracle.
@@ -62,18 +76,25 @@ type OracleBackend interface {
 // Oracle recommends gas prices based on the content of recent
 // blocks. Suitable for both light and full clients.
 type Oracle struct {
-	backend     OracleBackend
-	lastHead    common.Hash
-	lastPrice   *big.Int
-	maxPrice    *big.Int
-	ignorePrice *big.Int
-	cacheLock   sync.RWMutex
-	fetchLock   sync.Mutex
+	backend      OracleBackend
+	lastHead     common.Hash
+	lastPrice    *big.Int
+	defaultPrice *big.Int
+	maxPrice     *big.Int
+	ignorePrice  *big.Int
+	cacheLock    sync.RWMutex
+	fetchLock    sync.Mutex
 
 	checkBlocks, percentile           int
 	maxHeaderHistory, maxBlockHistory uint64
 
 	historyCache *lru.Cache[cacheKey, processedFees]
+
+	excludeSystemTxs bool // Whether to leave state-sync transactions out of the fee history
+
+	ignoreSenders map[common.Address]struct{} // Senders whose transactions are left out of the samples
+
+	processSub event.Subscription // Head subscription purging the history cache on reorgs
 }
 
 // NewOracle returns a new gasprice oracle which can recommend suitable
@@ -120,6 +141,15 @@ func NewOracle(backend OracleBackend, params Config) *Oracle {
 		log.Warn("Sanitizing invalid gasprice oracle max block history", "provided", params.MaxBlockHistory, "updated", maxBlockHistory)
 	}
 
+	ignoreSenders := make(map[common.Address]struct{}, len(params.IgnoreSenders))
+	for _, sender := range params.IgnoreSenders {
+		ignoreSenders[sender] = struct{}{}
+	}
+
+	if len(ignoreSenders) > 0 {
+		log.Info("Gasprice oracle is ignoring senders", "count", len(ignoreSenders))
+	}
+
 	cache := lru.NewCache[cacheKey, processedFees](2048)
 	headEvent := make(chan core.ChainHeadEvent, 1)
 	backend.SubscribeChainHeadEvent(headEvent)
@@ -138,6 +168,7 @@ func NewOracle(backend OracleBackend, params Config) *Oracle {
 	return &Oracle{
 		backend:          backend,
 		lastPrice:        params.Default,
+		defaultPrice:     params.Default,
 		maxPrice:         maxPrice,
 		ignorePrice:      ignorePrice,
 		checkBlocks:      blocks,
@@ -145,25 +176,115 @@ func NewOracle(backend OracleBackend, params Config) *Oracle {
 		maxHeaderHistory: maxHeaderHistory,
 		maxBlockHistory:  maxBlockHistory,
 		historyCache:     cache,
+		excludeSystemTxs: params.ExcludeSystemTxs,
+		ignoreSenders:    ignoreSenders,
 	}
 }
 
+// ProcessCache purges the fee history cache whenever a new head doesn't extend
+// the previous one. Calling it again replaces the running head subscription,
+// restarting the tracking from the current chain.
 func (oracle *Oracle) ProcessCache() {
 	headEvent := make(chan core.ChainHeadEvent, 1)
-	oracle.backend.SubscribeChainHeadEvent(headEvent)
+	sub := oracle.backend.SubscribeChainHeadEvent(headEvent)
+
+	oracle.cacheLock.Lock()
+	if oracle.processSub != nil {
+		oracle.processSub.Unsubscribe()
+	}
+	oracle.processSub = sub
+	oracle.cacheLock.Unlock()
 
 	go func() {
 		var lastHead common.Hash
-		for ev := range headEvent {
-			if ev.Block.ParentHash() != lastHead {
-				oracle.historyCache.Purge()
-			}
+		for {
+			select {
+			case ev := <-headEvent:
+				if ev.Block.ParentHash() != lastHead {
+					oracle.historyCache.Purge()
+				}
 
-			lastHead = ev.Block.Hash()
+				lastHead = ev.Block.Hash()
+
+			case <-sub.Err():
+				return
+			}
 		}
 	}()
 }
 
+// Reset drops the cached fee history, so the suggested price gets recomputed
+// from the recent blocks on the next request. If resetPrice is set, the last
+// suggested price also falls back to the configured default, as needed after
+// the chain has been reset to a genesis block and none of the previous samples
+// apply anymore.
+func (oracle *Oracle) Reset(resetPrice bool) {
+	oracle.historyCache.Purge()
+
+	oracle.cacheLock.Lock()
+	oracle.lastHead = common.Hash{}
+	if resetPrice {
+		oracle.lastPrice = oracle.defaultPrice
+	}
+	oracle.cacheLock.Unlock()
+}
+
+// Config returns the sampling parameters currently used by the oracle, i.e. the
+// number of blocks, the percentile, the price cap and the ignore price.
+func (oracle *Oracle) Config() Config {
+	oracle.fetchLock.Lock()
+	defer oracle.fetchLock.Unlock()
+
+	return oracle.samplingConfig()
+}
+
+// samplingConfig returns the sampling parameters of the oracle. The caller must
+// hold the fetch lock.
+func (oracle *Oracle) samplingConfig() Config {
+	return Config{
+		Blocks:      oracle.checkBlocks,
+		Percentile:  oracle.percentile,
+		MaxPrice:    new(big.Int).Set(oracle.maxPrice),
+		IgnorePrice: new(big.Int).Set(oracle.ignorePrice),
+	}
+}
+
+// Reconfigure atomically replaces the sampling parameters of the oracle, i.e.
+// the number of blocks, the percentile, the price cap and the ignore price, and
+// returns the previous ones. Unlike NewOracle, invalid values are rejected
+// instead of sanitized. The suggested price is recomputed on the next request.
+func (oracle *Oracle) Reconfigure(params Config) (Config, error) {
+	switch {
+	case params.Blocks < 1:
+		return Config{}, fmt.Errorf("%w: blocks %d, want at least 1", errInvalidOracleConfig, params.Blocks)
+	case params.Percentile < 0 || params.Percentile > 100:
+		return Config{}, fmt.Errorf("%w: percentile %d, want 0-100", errInvalidOracleConfig, params.Percentile)
+	case params.MaxPrice == nil || params.MaxPrice.Sign() <= 0:
+		return Config{}, fmt.Errorf("%w: max price %v, want positive", errInvalidOracleConfig, params.MaxPrice)
+	case params.IgnorePrice == nil || params.IgnorePrice.Sign() <= 0:
+		return Config{}, fmt.Errorf("%w: ignore price %v, want positive", errInvalidOracleConfig, params.IgnorePrice)
+	}
+
+	oracle.fetchLock.Lock()
+	defer oracle.fetchLock.Unlock()
+
+	previous := oracle.samplingConfig()
+
+	oracle.checkBlocks = params.Blocks
+	oracle.percentile = params.Percentile
+	oracle.maxPrice = new(big.Int).Set(params.MaxPrice)
+	oracle.ignorePrice = new(big.Int).Set(params.IgnorePrice)
+
+	oracle.cacheLock.Lock()
+	oracle.lastHead = common.Hash{}
+	oracle.cacheLock.Unlock()
+
+	log.Info("Reconfigured gasprice oracle", "blocks", params.Blocks, "percentile", params.Percentile,
+		"maxprice", params.MaxPrice, "ignoreprice", params.IgnorePrice)
+
+	return previous, nil
+}
+
 // SuggestTipCap returns a tip cap so that newly created transaction can have a
 // very high chance to be included in the following blocks.
 //
@@ -292,7 +413,7 @@ func (s *txSorter) Less(i, j int) bool {
 // getBlockPrices calculates the lowest transaction gas price in a given block
 // and sends it to the result channel. If the block is empty or all transactions
 // are sent by the miner itself(it doesn't make any sense to include this kind of
-// transaction prices for sampling), nil gasprice is returned.
+// transaction prices for sampling) or by ignored senders, nil gasprice is returned.
 func (oracle *Oracle) getBlockValues(ctx context.Context, signer types.Signer, blockNum uint64, limit int, ignoreUnder *big.Int, result chan results, quit chan struct{}) {
 	block, err := oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(blockNum))
 	if block == nil {
@@ -318,11 +439,17 @@ func (oracle *Oracle) getBlockValues(ctx context.Context, signer types.Signer, b
 		}
 
 		sender, err := types.Sender(signer, tx)
-		if err == nil && sender != block.Coinbase() {
-			prices = append(prices, tip)
-			if len(prices) >= limit {
-				break
-			}
+		if err != nil || sender == block.Coinbase() {
+			continue
+		}
+
+		if _, ignored := oracle.ignoreSenders[sender]; ignored {
+			continue
+		}
+
+		prices = append(prices, tip)
+		if len(prices) >= limit {
+			break
 		}
 	}
 	select {
@@ -18,6 +18,7 @@ package gasprice
 
 import (
 	"context"
+	"errors"
 	"math"
 	"math/big"
 	"testing"
@@ -235,3 +236,124 @@ func TestSuggestTipCap(t *testing.T) {
 		}
 	}
 }
+
+func TestSuggestTipCapAfterReset(t *testing.T) {
+	config := Config{
+		Blocks:     3,
+		Percentile: 60,
+		Default:    big.NewInt(params.GWei),
+	}
+
+	backend := newTestBackend(t, big.NewInt(0), false)
+	defer backend.teardown()
+
+	oracle := NewOracle(backend, config)
+
+	got, err := oracle.SuggestTipCap(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to retrieve recommended gas price: %v", err)
+	}
+
+	if want := big.NewInt(params.GWei * int64(30)); got.Cmp(want) != 0 {
+		t.Fatalf("Gas price mismatch, want %d, got %d", want, got)
+	}
+
+	// Reset to the genesis, the samples of the old chain must not leak into the
+	// suggestions for the new one
+	if err := backend.chain.ResetWithGenesisBlock(backend.chain.Genesis()); err != nil {
+		t.Fatalf("Failed to reset chain: %v", err)
+	}
+
+	oracle.Reset(true)
+
+	got, err = oracle.SuggestTipCap(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to retrieve recommended gas price: %v", err)
+	}
+
+	if got.Cmp(config.Default) != 0 {
+		t.Fatalf("Gas price mismatch after reset, want %d, got %d", config.Default, got)
+	}
+}
+
+func TestSuggestTipCapIgnoreSenders(t *testing.T) {
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+
+	config := Config{
+		Blocks:        3,
+		Percentile:    60,
+		Default:       big.NewInt(params.GWei),
+		IgnoreSenders: []common.Address{crypto.PubkeyToAddress(key.PublicKey)},
+	}
+
+	backend := newTestBackend(t, big.NewInt(0), false)
+	defer backend.teardown()
+
+	oracle := NewOracle(backend, config)
+
+	// All the transactions are sent by the ignored sender, so nothing is sampled
+	// and the suggestion falls back to the default
+	got, err := oracle.SuggestTipCap(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to retrieve recommended gas price: %v", err)
+	}
+
+	if got.Cmp(config.Default) != 0 {
+		t.Fatalf("Gas price mismatch, want %d, got %d", config.Default, got)
+	}
+}
+
+func TestReconfigure(t *testing.T) {
+	config := Config{
+		Blocks:     3,
+		Percentile: 60,
+		Default:    big.NewInt(params.GWei),
+	}
+
+	backend := newTestBackend(t, big.NewInt(0), false)
+	defer backend.teardown()
+
+	oracle := NewOracle(backend, config)
+
+	if _, err := oracle.SuggestTipCap(context.Background()); err != nil {
+		t.Fatalf("Failed to retrieve recommended gas price: %v", err)
+	}
+
+	// Invalid parameters are rejected without touching the oracle
+	invalid := []Config{
+		{Blocks: 0, Percentile: 60, MaxPrice: big.NewInt(1), IgnorePrice: big.NewInt(1)},
+		{Blocks: 3, Percentile: 101, MaxPrice: big.NewInt(1), IgnorePrice: big.NewInt(1)},
+		{Blocks: 3, Percentile: 60, IgnorePrice: big.NewInt(1)},
+		{Blocks: 3, Percentile: 60, MaxPrice: big.NewInt(1), IgnorePrice: big.NewInt(-1)},
+	}
+	for i, cfg := range invalid {
+		if _, err := oracle.Reconfigure(cfg); !errors.Is(err, errInvalidOracleConfig) {
+			t.Fatalf("config %d: error mismatch: have %v, want %v", i, err, errInvalidOracleConfig)
+		}
+	}
+
+	// A lower price cap applies immediately, and the previous config is returned
+	maxPrice := big.NewInt(params.Wei)
+
+	previous, err := oracle.Reconfigure(Config{Blocks: 5, Percentile: 40, MaxPrice: maxPrice, IgnorePrice: DefaultIgnorePrice})
+	if err != nil {
+		t.Fatalf("Failed to reconfigure oracle: %v", err)
+	}
+
+	if previous.Blocks != 3 || previous.Percentile != 60 || previous.MaxPrice.Cmp(DefaultMaxPrice) != 0 {
+		t.Fatalf("Previous config mismatch: have %+v", previous)
+	}
+
+	got, err := oracle.SuggestTipCap(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to retrieve recommended gas price: %v", err)
+	}
+
+	if got.Cmp(maxPrice) != 0 {
+		t.Fatalf("Gas price mismatch, want %d, got %d", maxPrice, got)
+	}
+
+	if have := oracle.Config(); have.Blocks != 5 || have.Percentile != 40 {
+		t.Fatalf("Config mismatch: have %+v", have)
+	}
+}
@@ -19,6 +19,7 @@ package eth
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 	"sync"
@@ -29,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -94,6 +96,8 @@ type handlerConfig struct {
 	EthAPI         *ethapi.BlockChainAPI     // EthAPI to interact
 	checker        ethereum.ChainValidator
 	txArrivalWait  time.Duration // Maximum duration to wait for an announced tx before requesting it
+
+	CheckpointRejections int // Number of recent checkpoint rejections kept for inspection
 }
 
 type handler struct {
@@ -126,6 +130,18 @@ type handler struct {
 
 	requiredBlocks map[uint64]common.Hash
 
+	checkpointNumberRequired uint64                 // End block of the latest whitelisted checkpoint, required from new peers
+	checkpointHashRequired   common.Hash            // End block hash of the latest whitelisted checkpoint
+	remoteRequiredBlocks     map[uint64]common.Hash // Required blocks refreshed from the configured remote source
+	remoteRequiredIssued     uint64                 // Issue time of the remote required blocks in use
+	requiredBlocksLock       sync.RWMutex           // Protects the checkpoint and remote contributed required blocks
+
+	heimdallCheckpoint   *checkpoint.Checkpoint // Latest raw checkpoint fetched from heimdall for whitelisting
+	heimdallCheckpointID int64                  // Number of the latest raw checkpoint fetched from heimdall
+	heimdallCheckpointMu sync.Mutex             // Protects the latest raw heimdall checkpoint
+
+	checkpointRejections *checkpointRejections // Recent heimdall checkpoints rejected by the whitelisting service
+
 	// channels for fetcher, syncer, txsyncLoop
 	quitSync chan struct{}
 
@@ -153,6 +169,8 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		ethAPI:         config.EthAPI,
 		requiredBlocks: config.RequiredBlocks,
 		quitSync:       make(chan struct{}),
+
+		checkpointRejections: newCheckpointRejections(config.CheckpointRejections),
 	}
 	if config.Sync == downloader.FullSync {
 		// The database seems empty as the current block is the genesis. Yet the snap
@@ -480,7 +498,7 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 		}()
 	}
 	// If we have any explicit peer required block hashes, request them
-	for number, hash := range h.requiredBlocks {
+	for number, hash := range h.currentRequiredBlocks() {
 		resCh := make(chan *eth.Response)
 
 		req, err := peer.RequestHeadersByNumber(number, 1, 0, false, resCh)
@@ -512,6 +530,7 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 
 				if headers[0].Number.Uint64() != number || headers[0].Hash() != hash {
 					peer.Log().Info("Required block mismatch, dropping peer", "number", number, "hash", headers[0].Hash(), "want", hash)
+					downloader.MarkRequiredBlockDrop()
 					res.Done <- errors.New("required block mismatch")
 
 					return
@@ -529,6 +548,61 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 	return handler(peer)
 }
 
+// setCheckpointRequiredBlock registers the end block of the latest whitelisted
+// checkpoint as a block required from new peers, replacing the previous one.
+func (h *handler) setCheckpointRequiredBlock(number uint64, hash common.Hash) {
+	h.requiredBlocksLock.Lock()
+	defer h.requiredBlocksLock.Unlock()
+
+	h.checkpointNumberRequired = number
+	h.checkpointHashRequired = hash
+}
+
+// setRemoteRequiredBlocks replaces the required blocks contributed by the remote
+// refresh service with the given set, unless it wasn't issued after the one in
+// use, so that older payloads can't roll the set back.
+func (h *handler) setRemoteRequiredBlocks(blocks map[uint64]common.Hash, issued uint64) error {
+	h.requiredBlocksLock.Lock()
+	defer h.requiredBlocksLock.Unlock()
+
+	switch {
+	case issued < h.remoteRequiredIssued:
+		return fmt.Errorf("%w: issued at %d, in use since %d", errRequiredBlocksStale, issued, h.remoteRequiredIssued)
+
+	case issued == h.remoteRequiredIssued && h.remoteRequiredBlocks != nil:
+		return errRequiredBlocksUnchanged
+	}
+
+	h.remoteRequiredBlocks = blocks
+	h.remoteRequiredIssued = issued
+
+	return nil
+}
+
+// currentRequiredBlocks returns the configured required blocks along with the
+// ones contributed by the checkpoint whitelist and the remote refresh services.
+// Explicitly configured blocks take precedence over remote ones, which in turn
+// take precedence over the checkpoint one.
+func (h *handler) currentRequiredBlocks() map[uint64]common.Hash {
+	h.requiredBlocksLock.RLock()
+	defer h.requiredBlocksLock.RUnlock()
+
+	required := make(map[uint64]common.Hash, len(h.requiredBlocks)+len(h.remoteRequiredBlocks)+1)
+	if h.checkpointHashRequired != (common.Hash{}) {
+		required[h.checkpointNumberRequired] = h.checkpointHashRequired
+	}
+
+	for number, hash := range h.remoteRequiredBlocks {
+		required[number] = hash
+	}
+
+	for number, hash := range h.requiredBlocks {
+		required[number] = hash
+	}
+
+	return required
+}
+
 // runSnapExtension registers a `snap` peer into the joint eth/snap peerset and
 // starts handling inbound messages. As `snap` is only a satellite protocol to
 // `eth`, all subsystem registrations and lifecycle management will be done by
@@ -545,6 +619,17 @@ func (h *handler) runSnapExtension(peer *snap.Peer, handler snap.Handler) error
 	return handler(peer)
 }
 
+// peerCount returns the number of eth peers currently connected.
+func (h *handler) peerCount() int {
+	return h.peers.len()
+}
+
+// peerProtocols returns the negotiated protocol versions and advertised heads of
+// the eth peers currently connected.
+func (h *handler) peerProtocols() []*PeerProtocols {
+	return h.peers.protocols()
+}
+
 // removePeer requests disconnection of a peer.
 func (h *handler) removePeer(id string) {
 	peer := h.peers.peer(id)
@@ -3,9 +3,13 @@ package eth
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -14,9 +18,10 @@ var (
 	// the checkpoint count from local heimdall.
 	errCheckpointCount = errors.New("failed to fetch checkpoint count")
 
-	// errNoCheckpoint is returned when there is not checkpoint proposed
-	// by heimdall yet or heimdall is not in sync
-	errNoCheckpoint = errors.New("no checkpoint proposed")
+	// ErrNoCheckpointsYet is returned when heimdall is reachable but hasn't
+	// proposed any checkpoint yet, as expected on a fresh network, or it's not
+	// in sync.
+	ErrNoCheckpointsYet = errors.New("no checkpoint proposed yet")
 
 	// errCheckpoint is returned when we are unable to fetch the
 	// latest checkpoint from the local heimdall.
@@ -36,11 +41,15 @@ var (
 
 	// errEndBlock is returned when we're unable to fetch a block locally.
 	errEndBlock = errors.New("failed to get end block")
+
+	// errNoHeimdallCheckpoint is returned when no checkpoint has been fetched
+	// from heimdall yet.
+	errNoHeimdallCheckpoint = errors.New("no checkpoint fetched from heimdall yet")
 )
 
 // fetchWhitelistCheckpoints fetches the latest checkpoint/s from it's local heimdall
-// and verifies the data against bor data.
-func (h *ethHandler) fetchWhitelistCheckpoints(ctx context.Context, bor *bor.Bor, checkpointVerifier *checkpointVerifier, first bool) ([]uint64, []common.Hash, error) {
+// and verifies the data against bor data, using up to the given number of workers.
+func (h *ethHandler) fetchWhitelistCheckpoints(ctx context.Context, heimdallClient bor.IHeimdallClient, checkpointVerifier *checkpointVerifier, first bool, workers int) ([]uint64, []common.Hash, error) {
 	// Create an array for block number and block hashes
 	//nolint:prealloc
 	var (
@@ -49,14 +58,16 @@ func (h *ethHandler) fetchWhitelistCheckpoints(ctx context.Context, bor *bor.Bor
 	)
 
 	// Fetch the checkpoint count from heimdall
-	count, err := bor.HeimdallClient.FetchCheckpointCount(ctx)
+	count, err := heimdallClient.FetchCheckpointCount(ctx)
 	if err != nil {
 		log.Debug("Failed to fetch checkpoint count for whitelisting", "err", err)
+		h.checkpointRejections.add(0, nil, fmt.Errorf("%w: %v", errCheckpointCount, err))
+
 		return blockNums, blockHashes, errCheckpointCount
 	}
 
 	if count == 0 {
-		return blockNums, blockHashes, errNoCheckpoint
+		return blockNums, blockHashes, ErrNoCheckpointsYet
 	}
 
 	var (
@@ -78,26 +89,140 @@ func (h *ethHandler) fetchWhitelistCheckpoints(ctx context.Context, bor *bor.Bor
 		start = count
 	}
 
-	for i := start; i <= end; i++ {
-		// fetch `i` indexed checkpoint from heimdall
-		checkpoint, err := bor.HeimdallClient.FetchCheckpoint(ctx, i)
-		if err != nil {
-			log.Debug("Failed to fetch latest checkpoint for whitelisting", "err", err)
-			return blockNums, blockHashes, errCheckpoint
-		}
-
-		// Verify if the checkpoint fetched can be added to the local whitelist entry or not
-		// If verified, it returns the hash of the end block of the checkpoint. If not,
-		// it will return appropriate error.
-
-		hash, err := checkpointVerifier.verify(ctx, h, checkpoint)
-		if err != nil {
-			return blockNums, blockHashes, err
-		}
-
-		blockNums = append(blockNums, checkpoint.EndBlock.Uint64())
-		blockHashes = append(blockHashes, common.HexToHash(hash))
+	return h.verifyWhitelistCheckpoints(ctx, heimdallClient, checkpointVerifier, start, end, workers)
+}
+
+// verifyWhitelistCheckpoints fetches and verifies the checkpoints in the given
+// range using up to the given number of workers. Only the checkpoints preceding
+// the first failure are returned, in order, along with the failure.
+func (h *ethHandler) verifyWhitelistCheckpoints(ctx context.Context, heimdallClient bor.IHeimdallClient, checkpointVerifier *checkpointVerifier, start, end int64, workers int) ([]uint64, []common.Hash, error) {
+	type result struct {
+		number uint64
+		hash   common.Hash
+		err    error
+	}
+
+	var (
+		count   = int(end - start + 1)
+		results = make([]result, count)
+		failed  atomic.Int64 // Index of the first failed checkpoint, count if none
+		next    = make(chan int)
+		wg      sync.WaitGroup
+	)
+
+	failed.Store(int64(count))
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	if workers > count {
+		workers = count
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range next {
+				// Checkpoints after a failed one won't be applied, don't bother
+				if int64(i) > failed.Load() {
+					continue
+				}
+
+				number, hash, err := h.fetchWhitelistCheckpoint(ctx, heimdallClient, checkpointVerifier, start+int64(i))
+				results[i] = result{number, hash, err}
+
+				if err != nil {
+					for {
+						first := failed.Load()
+						if int64(i) >= first || failed.CompareAndSwap(first, int64(i)) {
+							break
+						}
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		next <- i
+	}
+
+	close(next)
+	wg.Wait()
+
+	var (
+		first       = int(failed.Load())
+		blockNums   = make([]uint64, 0, first)
+		blockHashes = make([]common.Hash, 0, first)
+	)
+
+	for _, res := range results[:first] {
+		blockNums = append(blockNums, res.number)
+		blockHashes = append(blockHashes, res.hash)
+	}
+
+	if first < count {
+		return blockNums, blockHashes, results[first].err
 	}
 
 	return blockNums, blockHashes, nil
 }
+
+// fetchWhitelistCheckpoint fetches the checkpoint with the given number from
+// heimdall and verifies it against bor data, returning its end block number
+// and hash.
+func (h *ethHandler) fetchWhitelistCheckpoint(ctx context.Context, heimdallClient bor.IHeimdallClient, checkpointVerifier *checkpointVerifier, number int64) (uint64, common.Hash, error) {
+	checkpoint, err := heimdallClient.FetchCheckpoint(ctx, number)
+	if err != nil {
+		log.Debug("Failed to fetch latest checkpoint for whitelisting", "err", err)
+		h.checkpointRejections.add(number, nil, fmt.Errorf("%w: %v", errCheckpoint, err))
+
+		return 0, common.Hash{}, errCheckpoint
+	}
+
+	h.setHeimdallCheckpoint(number, checkpoint)
+
+	// Verify if the checkpoint fetched can be added to the local whitelist entry or not
+	// If verified, it returns the hash of the end block of the checkpoint. If not,
+	// it will return appropriate error.
+	hash, err := checkpointVerifier.verify(ctx, h, checkpoint)
+	if err != nil {
+		h.checkpointRejections.add(number, checkpoint, err)
+		return 0, common.Hash{}, err
+	}
+
+	return checkpoint.EndBlock.Uint64(), common.HexToHash(hash), nil
+}
+
+// setHeimdallCheckpoint caches the raw checkpoint with the given number as
+// fetched from heimdall, unless a later one was already seen.
+func (h *ethHandler) setHeimdallCheckpoint(number int64, checkpoint *checkpoint.Checkpoint) {
+	h.heimdallCheckpointMu.Lock()
+	defer h.heimdallCheckpointMu.Unlock()
+
+	if h.heimdallCheckpoint != nil && number < h.heimdallCheckpointID {
+		return
+	}
+
+	h.heimdallCheckpoint = checkpoint
+	h.heimdallCheckpointID = number
+}
+
+// recentCheckpointRejections returns the most recent heimdall checkpoints
+// rejected by the whitelisting service, oldest first.
+func (h *handler) recentCheckpointRejections() []CheckpointRejection {
+	return h.checkpointRejections.list()
+}
+
+// lastHeimdallCheckpoint returns the latest raw checkpoint fetched from heimdall
+// along with its number, or nil if none was fetched yet.
+func (h *handler) lastHeimdallCheckpoint() (*checkpoint.Checkpoint, int64) {
+	h.heimdallCheckpointMu.Lock()
+	defer h.heimdallCheckpointMu.Unlock()
+
+	return h.heimdallCheckpoint, h.heimdallCheckpointID
+}
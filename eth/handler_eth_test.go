@@ -324,6 +324,71 @@ func testRecvTransactions(t *testing.T, protocol uint) {
 	}
 }
 
+// Tests that the negotiated protocol versions and advertised head of connected
+// peers are reported.
+func TestPeerProtocols66(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler()
+	defer handler.close()
+
+	p2pSrc, p2pSink := p2p.MsgPipe()
+	defer p2pSrc.Close()
+	defer p2pSink.Close()
+
+	src := eth.NewPeer(eth.ETH66, p2p.NewPeerPipe(enode.ID{1}, "", nil, p2pSrc), p2pSrc, handler.txpool)
+	sink := eth.NewPeer(eth.ETH66, p2p.NewPeerPipe(enode.ID{2}, "", nil, p2pSink), p2pSink, handler.txpool)
+
+	defer src.Close()
+	defer sink.Close()
+
+	go handler.handler.runEthPeer(sink, func(peer *eth.Peer) error {
+		return eth.Handle((*ethHandler)(handler.handler), peer)
+	})
+
+	var (
+		genesis = handler.chain.Genesis()
+		head    = handler.chain.CurrentBlock()
+		td      = handler.chain.GetTd(head.Hash(), head.Number.Uint64())
+	)
+
+	if err := src.Handshake(1, td, head.Hash(), genesis.Hash(), forkid.NewIDWithChain(handler.chain), forkid.NewFilter(handler.chain)); err != nil {
+		t.Fatalf("failed to run protocol handshake: %v", err)
+	}
+	// The sink is registered right after the handshake, wait for it
+	var peers []*PeerProtocols
+
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(10 * time.Millisecond) {
+		if peers = handler.handler.peerProtocols(); len(peers) > 0 {
+			break
+		}
+	}
+
+	if len(peers) != 1 {
+		t.Fatalf("peer count mismatch: have %d, want %d", len(peers), 1)
+	}
+
+	if peers[0].ID != sink.ID() {
+		t.Errorf("peer id mismatch: have %s, want %s", peers[0].ID, sink.ID())
+	}
+
+	if peers[0].Eth != eth.ETH66 {
+		t.Errorf("eth version mismatch: have %d, want %d", peers[0].Eth, eth.ETH66)
+	}
+
+	if peers[0].Snap != 0 {
+		t.Errorf("snap version mismatch: have %d, want %d", peers[0].Snap, 0)
+	}
+
+	if peers[0].Head != head.Hash() {
+		t.Errorf("head mismatch: have %x, want %x", peers[0].Head, head.Hash())
+	}
+
+	if peers[0].Difficulty.Cmp(td) != 0 {
+		t.Errorf("difficulty mismatch: have %v, want %v", peers[0].Difficulty, td)
+	}
+}
+
 // This test checks that pending transactions are sent.
 func TestSendTransactions66(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,84 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+// miningHold is a set of reasons for pausing block creation, while leaving the
+// miner set up to resume once all of them are lifted.
+type miningHold uint8
+
+const (
+	miningHoldManual    miningHold = 1 << iota // Paused through miner_pause
+	miningHoldDiskSpace                        // Free disk space of the chain database below the minimum
+	miningHoldWhitelist                        // No checkpoint whitelisted within the staleness limit
+)
+
+// holdMining pauses block creation for the given reason, until it's released
+// along with all the other holds in place.
+func (s *Ethereum) holdMining(reason miningHold) {
+	s.miningHoldLock.Lock()
+	defer s.miningHoldLock.Unlock()
+
+	s.miningHolds |= reason
+
+	if s.miner != nil && !s.miner.Paused() {
+		s.miner.Pause()
+	}
+}
+
+// releaseMining lifts the hold of the given reason, resuming block creation if
+// no other holds are left.
+func (s *Ethereum) releaseMining(reason miningHold) {
+	s.miningHoldLock.Lock()
+	defer s.miningHoldLock.Unlock()
+
+	if s.miningHolds&reason == 0 {
+		return
+	}
+
+	s.miningHolds &^= reason
+
+	if s.miningHolds == 0 && s.miner != nil {
+		s.miner.Resume()
+	}
+}
+
+// miningHeld reports whether block creation is held for the given reason.
+func (s *Ethereum) miningHeld(reason miningHold) bool {
+	s.miningHoldLock.Lock()
+	defer s.miningHoldLock.Unlock()
+
+	return s.miningHolds&reason != 0
+}
+
+// startHeldMiner starts the miner, keeping block creation paused while any of
+// the automatic holds are in place. The explicit start lifts a manual pause.
+func (s *Ethereum) startHeldMiner() {
+	if s.miner == nil {
+		return
+	}
+
+	s.miningHoldLock.Lock()
+	defer s.miningHoldLock.Unlock()
+
+	s.miningHolds &^= miningHoldManual
+
+	s.miner.Start()
+
+	if s.miningHolds != 0 {
+		s.miner.Pause()
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newMiningTestBackend creates a bare backend with a live miner on top of an
+// in-memory chain, to exercise the mining holds. Closing the miner is left to
+// the caller.
+func newMiningTestBackend(t *testing.T, config *ethconfig.Config) *Ethereum {
+	t.Helper()
+
+	var (
+		gspec    = &core.Genesis{Config: params.TestChainConfig}
+		engine   = ethash.NewFaker()
+		chain, _ = core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+		poolConf = txpool.DefaultConfig
+	)
+
+	poolConf.Journal = ""
+
+	pool := txpool.NewTxPool(poolConf, params.TestChainConfig, chain)
+
+	s := &Ethereum{
+		config:        config,
+		blockchain:    chain,
+		txPool:        pool,
+		chaindataPath: "chaindata",
+	}
+	s.miner = miner.New(s, &miner.Config{Etherbase: common.HexToAddress("0x1")}, params.TestChainConfig, new(event.TypeMux), engine, nil)
+
+	t.Cleanup(func() {
+		pool.Stop()
+		chain.Stop()
+	})
+
+	return s
+}
@@ -17,6 +17,9 @@
 package eth
 
 import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 )
@@ -41,6 +44,38 @@ func (p *ethPeer) info() *ethPeerInfo {
 	}
 }
 
+// PeerProtocols represents the sub-protocol versions negotiated with a connected
+// peer along with the head it advertised, to spot peers on incompatible upgrades.
+type PeerProtocols struct {
+	ID         string      `json:"id"`             // Unique node identifier
+	Name       string      `json:"name"`           // Name of the node, including client type, version, OS, custom data
+	Eth        uint        `json:"eth"`            // Ethereum protocol version negotiated
+	Snap       uint        `json:"snap,omitempty"` // Snapshot protocol version negotiated, omitted if not running
+	Head       common.Hash `json:"head"`           // Hash of the peer's advertised head block
+	Difficulty *big.Int    `json:"difficulty"`     // Total difficulty of the peer's advertised head
+}
+
+// protocols gathers the negotiated protocol versions and advertised head of a peer.
+func (p *ethPeer) protocols() *PeerProtocols {
+	head, td := p.Head()
+
+	info := &PeerProtocols{
+		ID:         p.ID(),
+		Eth:        p.Version(),
+		Head:       head,
+		Difficulty: td,
+	}
+	if p.Peer.Peer != nil {
+		info.Name = p.Peer.Peer.Fullname()
+	}
+
+	if p.snapExt != nil {
+		info.Snap = p.snapExt.Version()
+	}
+
+	return info
+}
+
 // snapPeerInfo represents a short summary of the `snap` sub-protocol metadata known
 // about a connected peer.
 type snapPeerInfo struct {
@@ -19,6 +19,7 @@ package eth
 import (
 	"errors"
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -246,6 +247,25 @@ func (ps *peerSet) snapLen() int {
 	return ps.snapPeers
 }
 
+// protocols retrieves the negotiated protocol versions and advertised heads of
+// all the registered peers, sorted by peer id.
+func (ps *peerSet) protocols() []*PeerProtocols {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	list := make([]*PeerProtocols, 0, len(ps.peers))
+
+	for _, p := range ps.peers {
+		list = append(list, p.protocols())
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+
+	return list
+}
+
 // peerWithHighestTD retrieves the known peer with the currently highest total
 // difficulty, but below the given PoS switchover threshold.
 func (ps *peerSet) peerWithHighestTD() *eth.Peer {
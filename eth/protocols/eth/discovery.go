@@ -20,6 +20,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -66,3 +67,14 @@ func currentENREntry(chain *core.BlockChain) *enrEntry {
 		ForkID: forkid.NewID(chain.Config(), chain.Genesis().Hash(), head.Number.Uint64(), head.Time),
 	}
 }
+
+// LoadForkID retrieves the fork identifier advertised in the `eth` entry of the
+// given node record.
+func LoadForkID(r *enr.Record) (forkid.ID, error) {
+	var entry enrEntry
+	if err := r.Load(&entry); err != nil {
+		return forkid.ID{}, err
+	}
+
+	return entry.ForkID, nil
+}
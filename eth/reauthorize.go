@@ -0,0 +1,78 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// startEtherbaseReauthorizer watches the wallets of the account manager and
+// re-authorizes the consensus engine whenever the wallet holding the etherbase
+// arrives or is opened, as the engine would otherwise keep signing through the
+// stale closure of a dropped wallet.
+func (s *Ethereum) startEtherbaseReauthorizer() {
+	if !s.config.Miner.ReauthorizeEtherbase {
+		return
+	}
+
+	events := make(chan accounts.WalletEvent, 16)
+	sub := s.accountManager.Subscribe(events)
+
+	go func() {
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case event := <-events:
+				s.handleWalletEvent(event)
+
+			case <-sub.Err():
+				return
+
+			case <-s.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// handleWalletEvent re-authorizes the consensus engine with the wallet of the
+// event if it (re)appeared holding the etherbase. It reports whether the engine
+// was re-authorized.
+func (s *Ethereum) handleWalletEvent(event accounts.WalletEvent) bool {
+	if event.Kind != accounts.WalletArrived && event.Kind != accounts.WalletOpened {
+		return false
+	}
+
+	s.lock.RLock()
+	eb := s.etherbase
+	s.lock.RUnlock()
+
+	if eb == (common.Address{}) || !event.Wallet.Contains(accounts.Account{Address: eb}) {
+		return false
+	}
+
+	if !s.authorizeEngine(eb, event.Wallet) {
+		return false
+	}
+
+	log.Info("Re-authorized etherbase", "address", eb, "wallet", event.Wallet.URL())
+
+	return true
+}
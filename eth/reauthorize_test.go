@@ -0,0 +1,117 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the engine is re-authorized when the etherbase wallet is dropped
+// and re-added, but not on unrelated wallet events.
+func TestEtherbaseReauthorization(t *testing.T) {
+	t.Parallel()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	am := accounts.NewManager(&accounts.Config{}, ks)
+
+	defer am.Close()
+
+	events := make(chan accounts.WalletEvent, 16)
+	sub := am.Subscribe(events)
+
+	defer sub.Unsubscribe()
+
+	next := func(want accounts.WalletEventType) accounts.WalletEvent {
+		t.Helper()
+
+		select {
+		case event := <-events:
+			if event.Kind != want {
+				t.Fatalf("wallet event mismatch: have %v, want %v", event.Kind, want)
+			}
+
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatalf("wallet event %v not delivered", want)
+		}
+
+		return accounts.WalletEvent{}
+	}
+
+	etherbase, err := ks.NewAccount("")
+	if err != nil {
+		t.Fatalf("failed to create etherbase: %v", err)
+	}
+
+	arrived := next(accounts.WalletArrived)
+
+	other, err := ks.NewAccount("")
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	unrelated := next(accounts.WalletArrived)
+
+	s := &Ethereum{
+		engine:         clique.New(params.AllCliqueProtocolChanges.Clique, rawdb.NewMemoryDatabase()),
+		accountManager: am,
+		etherbase:      etherbase.Address,
+	}
+
+	if !s.handleWalletEvent(arrived) {
+		t.Fatalf("engine not authorized on etherbase arrival")
+	}
+
+	if s.handleWalletEvent(unrelated) {
+		t.Fatalf("engine authorized with wallet of %v", other.Address)
+	}
+
+	// Drop the etherbase wallet and add it back
+	keyjson, err := ks.Export(etherbase, "", "")
+	if err != nil {
+		t.Fatalf("failed to export etherbase: %v", err)
+	}
+
+	if err := ks.Delete(etherbase, ""); err != nil {
+		t.Fatalf("failed to drop etherbase: %v", err)
+	}
+
+	if s.handleWalletEvent(next(accounts.WalletDropped)) {
+		t.Fatalf("engine authorized on etherbase drop")
+	}
+
+	if _, err := ks.Import(keyjson, "", ""); err != nil {
+		t.Fatalf("failed to re-add etherbase: %v", err)
+	}
+
+	if !s.handleWalletEvent(next(accounts.WalletArrived)) {
+		t.Fatalf("engine not re-authorized on etherbase re-add")
+	}
+
+	// Engines sealing without a local signer are left alone
+	s.engine = nil
+	if s.handleWalletEvent(arrived) {
+		t.Fatalf("engine without local signer authorized")
+	}
+}
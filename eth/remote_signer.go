@@ -0,0 +1,57 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrRemoteSignerUnreachable is returned by StartMining if the configured
+	// remote signer can't be reached.
+	ErrRemoteSignerUnreachable = errors.New("remote signer unreachable")
+
+	// ErrRemoteSignerAccount is returned by StartMining if the configured remote
+	// signer doesn't manage the etherbase account.
+	ErrRemoteSignerAccount = errors.New("etherbase not managed by the remote signer")
+)
+
+// remoteSigner is a wallet backed by a remote signer (clef), sealing blocks on
+// behalf of the consensus engine.
+type remoteSigner struct {
+	*external.ExternalSigner
+}
+
+// newRemoteSigner connects to the remote signer at the given endpoint, checking
+// it's reachable and manages the etherbase account.
+func newRemoteSigner(endpoint string, etherbase common.Address) (*remoteSigner, error) {
+	signer, err := external.NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRemoteSignerUnreachable, err)
+	}
+
+	if !signer.Contains(accounts.Account{Address: etherbase}) {
+		return nil, fmt.Errorf("%w: %v", ErrRemoteSignerAccount, etherbase)
+	}
+
+	return &remoteSigner{signer}, nil
+}
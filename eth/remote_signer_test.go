@@ -0,0 +1,210 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core"
+	"github.com/ethereum/go-ethereum/signer/storage"
+)
+
+// testClefUI is a clef user interface approving every request, unlocking the
+// accounts with a fixed password.
+type testClefUI struct{}
+
+func (ui *testClefUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse, error) {
+	return core.SignTxResponse{Transaction: request.Transaction, Approved: true}, nil
+}
+
+func (ui *testClefUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
+	return core.SignDataResponse{Approved: true}, nil
+}
+
+func (ui *testClefUI) ApproveListing(request *core.ListRequest) (core.ListResponse, error) {
+	return core.ListResponse{Accounts: request.Accounts}, nil
+}
+
+func (ui *testClefUI) ApproveNewAccount(request *core.NewAccountRequest) (core.NewAccountResponse, error) {
+	return core.NewAccountResponse{Approved: false}, nil
+}
+
+func (ui *testClefUI) OnInputRequired(info core.UserInputRequest) (core.UserInputResponse, error) {
+	return core.UserInputResponse{Text: "password"}, nil
+}
+
+func (ui *testClefUI) ShowError(message string)                     {}
+func (ui *testClefUI) ShowInfo(message string)                      {}
+func (ui *testClefUI) OnApprovedTx(tx ethapi.SignTransactionResult) {}
+func (ui *testClefUI) OnSignerStartup(info core.StartupInfo)        {}
+func (ui *testClefUI) RegisterUIServer(api *core.UIServerAPI)       {}
+
+// startTestClef starts a clef instance managing a single account with the given
+// key, returning its endpoint. It's stopped when the test finishes.
+func startTestClef(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+
+	keydir := t.TempDir()
+	if _, err := keystore.NewKeyStore(keydir, keystore.LightScryptN, keystore.LightScryptP).ImportECDSA(key, "password"); err != nil {
+		t.Fatalf("failed to import signer key: %v", err)
+	}
+
+	manager := core.StartClefAccountManager(keydir, true, true, "")
+	t.Cleanup(func() { manager.Close() })
+
+	srv := rpc.NewServer("", 0, 0)
+	t.Cleanup(srv.Stop)
+
+	if err := srv.RegisterName("account", core.NewSignerAPI(manager, 1337, true, &testClefUI{}, nil, false, &storage.NoStorage{})); err != nil {
+		t.Fatalf("failed to register remote signer: %v", err)
+	}
+
+	clef := httptest.NewServer(srv)
+	t.Cleanup(clef.Close)
+
+	return clef.URL
+}
+
+func TestRemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	// Start a clef instance managing a single account
+	key, _ := crypto.GenerateKey()
+	account := crypto.PubkeyToAddress(key.PublicKey)
+
+	clef := startTestClef(t, key)
+
+	if _, err := newRemoteSigner("http://127.0.0.1:1", account); !errors.Is(err, ErrRemoteSignerUnreachable) {
+		t.Fatalf("unreachable error mismatch: have %v, want %v", err, ErrRemoteSignerUnreachable)
+	}
+
+	if _, err := newRemoteSigner(clef, common.HexToAddress("0x2")); !errors.Is(err, ErrRemoteSignerAccount) {
+		t.Fatalf("account error mismatch: have %v, want %v", err, ErrRemoteSignerAccount)
+	}
+
+	signer, err := newRemoteSigner(clef, account)
+	if err != nil {
+		t.Fatalf("failed to connect to the remote signer: %v", err)
+	}
+
+	// Seal a bor header both before and after Jaipur, checking it recovers to the signer
+	config := &params.BorConfig{JaipurBlock: big.NewInt(10)}
+
+	for _, number := range []int64{1, 10} {
+		header := &types.Header{
+			Number:     big.NewInt(number),
+			Difficulty: big.NewInt(1),
+			Extra:      make([]byte, 32+65),
+			BaseFee:    big.NewInt(params.InitialBaseFee),
+		}
+
+		sig, err := signer.SignData(accounts.Account{Address: account}, accounts.MimetypeBor, bor.BorRLP(header, config))
+		if err != nil {
+			t.Fatalf("failed to sign bor header %d: %v", number, err)
+		}
+
+		if sig[64] > 1 {
+			t.Fatalf("header %d signature V mismatch: have %d, want 0 or 1", number, sig[64])
+		}
+
+		pubkey, err := crypto.Ecrecover(bor.SealHash(header, config).Bytes(), sig)
+		if err != nil {
+			t.Fatalf("failed to recover header %d signer: %v", number, err)
+		}
+
+		var signer common.Address
+		copy(signer[:], crypto.Keccak256(pubkey[1:])[12:])
+
+		if signer != account {
+			t.Fatalf("header %d signer mismatch: have %v, want %v", number, signer, account)
+		}
+	}
+}
+
+// Tests that the etherbase candidates are looked up in the local keystores, or on
+// the remote signer if one is configured, like the etherbase itself.
+func TestEtherbaseCandidateSigner(t *testing.T) {
+	t.Parallel()
+
+	var (
+		missing = common.HexToAddress("0x1")
+		initial = errors.New("etherbase unavailable")
+		ks      = keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	)
+
+	local, err := ks.NewAccount("")
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	// The account manager only sees new accounts asynchronously, so the
+	// keystore is populated before the manager is created
+	manager := accounts.NewManager(&accounts.Config{}, ks)
+	defer manager.Close()
+
+	remoteKey, _ := crypto.GenerateKey()
+	remote := crypto.PubkeyToAddress(remoteKey.PublicKey)
+	clef := startTestClef(t, remoteKey)
+
+	newBackend := func(remoteSigner string, candidates ...common.Address) *Ethereum {
+		config := &ethconfig.Config{RemoteSigner: remoteSigner}
+		config.Miner.EtherbaseCandidates = candidates
+
+		return &Ethereum{config: config, accountManager: manager}
+	}
+
+	// Without a remote signer, the first candidate in the keystores is picked
+	candidate, wallet, err := newBackend("", missing, remote, local.Address).etherbaseCandidateSigner(initial)
+	if err != nil {
+		t.Fatalf("failed to pick local candidate: %v", err)
+	}
+
+	if candidate != local.Address || wallet == nil {
+		t.Fatalf("local candidate mismatch: have %v, want %v", candidate, local.Address)
+	}
+
+	// With a remote signer, the first candidate it manages is picked, even if
+	// another one is available locally
+	candidate, wallet, err = newBackend(clef, missing, local.Address, remote).etherbaseCandidateSigner(initial)
+	if err != nil {
+		t.Fatalf("failed to pick remote candidate: %v", err)
+	}
+
+	if candidate != remote || wallet == nil {
+		t.Fatalf("remote candidate mismatch: have %v, want %v", candidate, remote)
+	}
+
+	// If no candidate is usable, all the errors are reported
+	_, _, err = newBackend(clef, missing, local.Address).etherbaseCandidateSigner(initial)
+	if !errors.Is(err, initial) || !errors.Is(err, ErrRemoteSignerAccount) {
+		t.Fatalf("candidate error mismatch: have %v, want %v and %v", err, initial, ErrRemoteSignerAccount)
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// requiredBlocksTimeout is the maximum time allowed to fetch the remote
+	// required blocks.
+	requiredBlocksTimeout = 30 * time.Second
+
+	// defaultRequiredBlocksRefreshInterval is the interval at which the remote
+	// required blocks are refreshed if none is configured.
+	defaultRequiredBlocksRefreshInterval = 10 * time.Minute
+
+	// maxRequiredBlocksPayload is the maximum size of a remote required blocks
+	// payload, in bytes.
+	maxRequiredBlocksPayload = 1024 * 1024
+
+	// maxRequiredBlocks is the maximum number of entries a remote required
+	// blocks payload may contain.
+	maxRequiredBlocks = 1024
+)
+
+var (
+	// errRequiredBlocksSignature is returned if a remote required blocks payload
+	// isn't signed by the configured signer.
+	errRequiredBlocksSignature = errors.New("required blocks not signed by the configured signer")
+
+	// errRequiredBlocksInvalid is returned if a remote required blocks payload
+	// contains malformed entries.
+	errRequiredBlocksInvalid = errors.New("invalid required blocks")
+
+	// errRequiredBlocksStale is returned if a remote required blocks payload was
+	// issued before the one in use, e.g. if an old payload is replayed.
+	errRequiredBlocksStale = errors.New("required blocks older than the ones in use")
+
+	// errRequiredBlocksUnchanged is returned if a remote required blocks payload
+	// was issued at the same time as the one in use.
+	errRequiredBlocksUnchanged = errors.New("required blocks unchanged")
+)
+
+// requiredBlock is a single block number -> hash mapping of a remote payload.
+type requiredBlock struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+}
+
+// requiredBlocksPayload is the document served by the remote required blocks
+// source, where the signature covers requiredBlocksDigest of the payload. The
+// issue time, in unix seconds, must increase with every new payload so older
+// ones can't be replayed.
+type requiredBlocksPayload struct {
+	ChainID   *hexutil.Big    `json:"chainId"`
+	Issued    hexutil.Uint64  `json:"issued"`
+	Blocks    []requiredBlock `json:"blocks"`
+	Signature hexutil.Bytes   `json:"signature"`
+}
+
+// requiredBlocksDigest returns the hash signed by the remote source: the keccak
+// of the 32 byte chain id, the big endian issue time, and the big endian numbers
+// and hashes of the blocks, sorted by number.
+func requiredBlocksDigest(chainID *big.Int, issued uint64, blocks []requiredBlock) common.Hash {
+	sorted := make([]requiredBlock, len(blocks))
+	copy(sorted, blocks)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Number < sorted[j].Number
+	})
+
+	data := make([]byte, 0, common.HashLength+8+len(sorted)*(8+common.HashLength))
+
+	if chainID != nil {
+		data = append(data, common.BigToHash(chainID).Bytes()...)
+	} else {
+		data = append(data, make([]byte, common.HashLength)...)
+	}
+
+	data = binary.BigEndian.AppendUint64(data, issued)
+
+	for _, block := range sorted {
+		data = binary.BigEndian.AppendUint64(data, uint64(block.Number))
+		data = append(data, block.Hash[:]...)
+	}
+
+	return crypto.Keccak256Hash(data)
+}
+
+// verifyRequiredBlocks checks the signature, the chain id and the entries of a
+// payload and returns its blocks as a number -> hash set.
+func verifyRequiredBlocks(payload *requiredBlocksPayload, signer common.Address, chainID *big.Int) (map[uint64]common.Hash, error) {
+	if len(payload.Blocks) > maxRequiredBlocks {
+		return nil, fmt.Errorf("%w: %d entries, limit %d", errRequiredBlocksInvalid, len(payload.Blocks), maxRequiredBlocks)
+	}
+
+	if len(payload.Signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%w: signature length %d", errRequiredBlocksSignature, len(payload.Signature))
+	}
+
+	pubkey, err := crypto.SigToPub(requiredBlocksDigest((*big.Int)(payload.ChainID), uint64(payload.Issued), payload.Blocks).Bytes(), payload.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errRequiredBlocksSignature, err)
+	}
+
+	if recovered := crypto.PubkeyToAddress(*pubkey); recovered != signer {
+		return nil, fmt.Errorf("%w: signed by %s", errRequiredBlocksSignature, recovered)
+	}
+
+	if payload.ChainID == nil || payload.ChainID.ToInt().Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("%w: chain id %v, want %v", errRequiredBlocksInvalid, payload.ChainID, chainID)
+	}
+
+	blocks := make(map[uint64]common.Hash, len(payload.Blocks))
+
+	for _, block := range payload.Blocks {
+		number := uint64(block.Number)
+
+		if block.Hash == (common.Hash{}) {
+			return nil, fmt.Errorf("%w: empty hash for block #%d", errRequiredBlocksInvalid, number)
+		}
+
+		if _, ok := blocks[number]; ok {
+			return nil, fmt.Errorf("%w: duplicate block #%d", errRequiredBlocksInvalid, number)
+		}
+
+		blocks[number] = block.Hash
+	}
+
+	return blocks, nil
+}
+
+// fetchRequiredBlocks downloads the required blocks served at the given url and
+// verifies they are signed by the given signer for the given chain, returning
+// them along with their issue time.
+func fetchRequiredBlocks(ctx context.Context, url string, signer common.Address, chainID *big.Int) (map[uint64]common.Hash, uint64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("unexpected status: %s", res.Status)
+	}
+
+	var payload requiredBlocksPayload
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxRequiredBlocksPayload)).Decode(&payload); err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", errRequiredBlocksInvalid, err)
+	}
+
+	blocks, err := verifyRequiredBlocks(&payload, signer, chainID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return blocks, uint64(payload.Issued), nil
+}
+
+// startRequiredBlocksRefreshService periodically refreshes the required blocks
+// served by the configured remote source, requiring them from new peers along
+// with the statically configured ones. It's a no-op unless a url is set.
+func (s *Ethereum) startRequiredBlocksRefreshService() {
+	if s.config.RequiredBlocksURL == "" {
+		return
+	}
+
+	if s.config.RequiredBlocksSigner == (common.Address{}) {
+		log.Error("Remote required blocks disabled, no signer configured", "url", s.config.RequiredBlocksURL)
+		return
+	}
+
+	interval := s.config.RequiredBlocksRefreshInterval
+	if interval <= 0 {
+		interval = defaultRequiredBlocksRefreshInterval
+	}
+
+	log.Info("Refreshing remote required blocks", "url", s.config.RequiredBlocksURL, "signer", s.config.RequiredBlocksSigner, "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.refreshRequiredBlocks()
+
+		select {
+		case <-ticker.C:
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// refreshRequiredBlocks fetches the remote required blocks and replaces the ones
+// of the handler. The previous set is kept if the fetch fails, or if the fetched
+// one wasn't issued after it.
+func (s *Ethereum) refreshRequiredBlocks() {
+	ctx, cancel := s.closeContext()
+	defer cancel()
+
+	ctx, cancel = context.WithTimeout(ctx, requiredBlocksTimeout)
+	defer cancel()
+
+	blocks, issued, err := fetchRequiredBlocks(ctx, s.config.RequiredBlocksURL, s.config.RequiredBlocksSigner, s.blockchain.Config().ChainID)
+	if err == nil {
+		err = s.handler.setRemoteRequiredBlocks(blocks, issued)
+	}
+
+	switch {
+	case errors.Is(err, errRequiredBlocksUnchanged):
+		log.Debug("Remote required blocks unchanged", "issued", issued)
+
+	case err != nil:
+		log.Warn("Failed to refresh remote required blocks", "url", s.config.RequiredBlocksURL, "err", err)
+
+	default:
+		log.Debug("Refreshed remote required blocks", "count", len(blocks), "issued", issued)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// signRequiredBlocks builds a required blocks payload for the given chain, issued
+// at the given time and signed with the given key.
+func signRequiredBlocks(t *testing.T, chainID *big.Int, issued uint64, blocks []requiredBlock, key []byte) *requiredBlocksPayload {
+	t.Helper()
+
+	priv, err := crypto.ToECDSA(key)
+	if err != nil {
+		t.Fatalf("failed to load key: %v", err)
+	}
+
+	sig, err := crypto.Sign(requiredBlocksDigest(chainID, issued, blocks).Bytes(), priv)
+	if err != nil {
+		t.Fatalf("failed to sign required blocks: %v", err)
+	}
+
+	return &requiredBlocksPayload{ChainID: (*hexutil.Big)(chainID), Issued: hexutil.Uint64(issued), Blocks: blocks, Signature: sig}
+}
+
+func TestFetchRequiredBlocks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		signerKey = common.FromHex("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		otherKey  = common.FromHex("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		blocks    = []requiredBlock{{Number: 20, Hash: common.Hash{0x02}}, {Number: 10, Hash: common.Hash{0x01}}}
+		chainID   = big.NewInt(137)
+	)
+
+	priv, _ := crypto.ToECDSA(signerKey)
+	signer := crypto.PubkeyToAddress(priv.PublicKey)
+
+	tampered := signRequiredBlocks(t, chainID, 100, blocks, signerKey)
+	tampered.Blocks = []requiredBlock{{Number: 10, Hash: common.Hash{0xff}}, blocks[0]}
+
+	backdated := signRequiredBlocks(t, chainID, 100, blocks, signerKey)
+	backdated.Issued = 50
+
+	rechained := signRequiredBlocks(t, chainID, 100, blocks, signerKey)
+	rechained.ChainID = (*hexutil.Big)(big.NewInt(80001))
+
+	testCases := []struct {
+		name     string
+		payload  *requiredBlocksPayload
+		expected map[uint64]common.Hash
+		err      error
+	}{
+		{"valid", signRequiredBlocks(t, chainID, 100, blocks, signerKey), map[uint64]common.Hash{10: {0x01}, 20: {0x02}}, nil},
+		{"empty", signRequiredBlocks(t, chainID, 100, nil, signerKey), map[uint64]common.Hash{}, nil},
+		{"wrong signer", signRequiredBlocks(t, chainID, 100, blocks, otherKey), nil, errRequiredBlocksSignature},
+		{"tampered", tampered, nil, errRequiredBlocksSignature},
+		{"backdated", backdated, nil, errRequiredBlocksSignature},
+		{"other chain signature", rechained, nil, errRequiredBlocksSignature},
+		{"other chain", signRequiredBlocks(t, big.NewInt(80001), 100, blocks, signerKey), nil, errRequiredBlocksInvalid},
+		{"no chain", signRequiredBlocks(t, nil, 100, blocks, signerKey), nil, errRequiredBlocksInvalid},
+		{"unsigned", &requiredBlocksPayload{ChainID: (*hexutil.Big)(chainID), Blocks: blocks}, nil, errRequiredBlocksSignature},
+		{"duplicate", signRequiredBlocks(t, chainID, 100, append(blocks, requiredBlock{Number: 10, Hash: common.Hash{0x03}}), signerKey), nil, errRequiredBlocksInvalid},
+		{"empty hash", signRequiredBlocks(t, chainID, 100, []requiredBlock{{Number: 10}}, signerKey), nil, errRequiredBlocksInvalid},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(tc.payload)
+			}))
+			defer server.Close()
+
+			have, issued, err := fetchRequiredBlocks(context.Background(), server.URL, signer, chainID)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("error mismatch: have %v, want %v", err, tc.err)
+			}
+
+			if tc.err == nil && (!reflect.DeepEqual(have, tc.expected) || issued != 100) {
+				t.Fatalf("required blocks mismatch: have %v issued at %d, want %v issued at 100", have, issued, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCurrentRequiredBlocksPrecedence(t *testing.T) {
+	t.Parallel()
+
+	h := &handler{requiredBlocks: map[uint64]common.Hash{10: {0x01}}}
+
+	h.setCheckpointRequiredBlock(30, common.Hash{0x30})
+	if err := h.setRemoteRequiredBlocks(map[uint64]common.Hash{10: {0xff}, 20: {0x02}, 30: {0x03}}, 100); err != nil {
+		t.Fatalf("failed to set remote required blocks: %v", err)
+	}
+
+	expected := map[uint64]common.Hash{10: {0x01}, 20: {0x02}, 30: {0x03}}
+	if have := h.currentRequiredBlocks(); !reflect.DeepEqual(have, expected) {
+		t.Fatalf("required blocks mismatch: have %v, want %v", have, expected)
+	}
+}
+
+// Tests that remote required blocks are only replaced by payloads issued after
+// the ones in use, so that replaying an older payload can't roll them back.
+func TestRemoteRequiredBlocksReplay(t *testing.T) {
+	t.Parallel()
+
+	var (
+		h     = new(handler)
+		older = map[uint64]common.Hash{10: {0x01}}
+		newer = map[uint64]common.Hash{10: {0x01}, 20: {0x02}}
+	)
+
+	if err := h.setRemoteRequiredBlocks(older, 100); err != nil {
+		t.Fatalf("failed to set initial required blocks: %v", err)
+	}
+
+	if err := h.setRemoteRequiredBlocks(newer, 200); err != nil {
+		t.Fatalf("failed to set newer required blocks: %v", err)
+	}
+
+	if err := h.setRemoteRequiredBlocks(older, 100); !errors.Is(err, errRequiredBlocksStale) {
+		t.Fatalf("replay error mismatch: have %v, want %v", err, errRequiredBlocksStale)
+	}
+
+	if err := h.setRemoteRequiredBlocks(map[uint64]common.Hash{}, 200); !errors.Is(err, errRequiredBlocksUnchanged) {
+		t.Fatalf("refetch error mismatch: have %v, want %v", err, errRequiredBlocksUnchanged)
+	}
+
+	if have := h.currentRequiredBlocks(); !reflect.DeepEqual(have, newer) {
+		t.Fatalf("required blocks mismatch: have %v, want %v", have, newer)
+	}
+}
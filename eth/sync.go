@@ -73,7 +73,8 @@ type chainSyncer struct {
 	forced      bool // true when force timer fired
 	warned      time.Time
 	peerEventCh chan struct{}
-	doneCh      chan error // non-nil when sync is running
+	doneCh      chan error          // non-nil when sync is running
+	mode        downloader.SyncMode // mode of the last scheduled sync cycle
 }
 
 // chainSyncOp is a scheduled sync operation.
@@ -86,9 +87,15 @@ type chainSyncOp struct {
 
 // newChainSyncer creates a chainSyncer.
 func newChainSyncer(handler *handler) *chainSyncer {
+	mode := downloader.FullSync
+	if atomic.LoadUint32(&handler.snapSync) == 1 {
+		mode = downloader.SnapSync
+	}
+
 	return &chainSyncer{
 		handler:     handler,
 		peerEventCh: make(chan struct{}),
+		mode:        mode,
 	}
 }
 
@@ -253,8 +260,24 @@ func (cs *chainSyncer) modeAndLocalHead() (downloader.SyncMode, *big.Int) {
 	// return downloader.FullSync, td
 }
 
+// setMode records the mode of the next sync cycle, posting a SyncModeChangeEvent
+// if it differs from the previous one.
+func (cs *chainSyncer) setMode(mode downloader.SyncMode) {
+	if mode == cs.mode {
+		return
+	}
+
+	head := cs.handler.chain.CurrentBlock().Number.Uint64()
+	log.Info("Sync mode changed", "old", cs.mode, "new", mode, "head", head)
+
+	cs.handler.eventMux.Post(downloader.SyncModeChangeEvent{Old: cs.mode, New: mode, Head: head})
+	cs.mode = mode
+}
+
 // startSync launches doSync in a new goroutine.
 func (cs *chainSyncer) startSync(op *chainSyncOp) {
+	cs.setMode(op.mode)
+
 	cs.doneCh = make(chan error, 1)
 	go func() { cs.doneCh <- cs.handler.doSync(op) }()
 }
@@ -21,11 +21,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 // Tests that snap sync is disabled after a successful sync cycle.
@@ -101,3 +107,53 @@ func testSnapSyncDisabling(t *testing.T, ethVer uint, snapVer uint) {
 		t.Fatalf("snap sync not disabled after successful synchronisation")
 	}
 }
+
+// Tests that sync mode transitions are posted on the event mux, along with the
+// local head at the time of the transition.
+func TestSyncModeChangeEvent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gspec    = &core.Genesis{Config: params.TestChainConfig}
+		_, bs, _ = core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 3, nil)
+		chain, _ = core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	)
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(bs); err != nil {
+		t.Fatalf("failed to import blocks: %v", err)
+	}
+
+	mux := new(event.TypeMux)
+	defer mux.Stop()
+
+	sub := mux.Subscribe(downloader.SyncModeChangeEvent{})
+	defer sub.Unsubscribe()
+
+	cs := newChainSyncer(&handler{chain: chain, eventMux: mux})
+
+	// Posting blocks until delivered, so run the transitions in the background
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cs.setMode(downloader.FullSync)
+	}()
+
+	select {
+	case ev := <-sub.Chan():
+		t.Fatalf("unexpected event without a transition: %v", ev.Data)
+	case <-done:
+	}
+
+	go cs.setMode(downloader.SnapSync)
+
+	select {
+	case ev := <-sub.Chan():
+		want := downloader.SyncModeChangeEvent{Old: downloader.FullSync, New: downloader.SnapSync, Head: 3}
+		if ev.Data != want {
+			t.Fatalf("event mismatch: have %+v, want %+v", ev.Data, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("sync mode change not posted")
+	}
+}
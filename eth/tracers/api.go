@@ -82,6 +82,10 @@ var defaultBorTraceEnabled = newBoolPtr(false)
 
 var errTxNotFound = errors.New("transaction not found")
 
+// errTooManyTraces is returned if a tracing request arrives while the maximum
+// number of concurrent traces is already being served.
+var errTooManyTraces = errors.New("too many tracing requests")
+
 // StateReleaseFunc is used to deallocate resources held by constructing a
 // historical state for tracing purposes.
 type StateReleaseFunc func()
@@ -110,11 +114,44 @@ type Backend interface {
 // API is the collection of tracing APIs exposed over the private debugging endpoint.
 type API struct {
 	backend Backend
+	traces  chan struct{} // Semaphore limiting the concurrent traces, nil if unlimited
+}
+
+// traceLimiter is implemented by backends which restrict the number of
+// tracing requests served at the same time.
+type traceLimiter interface {
+	MaxConcurrentTraces() int
 }
 
 // NewAPI creates a new API definition for the tracing methods of the Ethereum service.
 func NewAPI(backend Backend) *API {
-	return &API{backend: backend}
+	api := &API{backend: backend}
+	if limiter, ok := backend.(traceLimiter); ok && limiter.MaxConcurrentTraces() > 0 {
+		api.traces = make(chan struct{}, limiter.MaxConcurrentTraces())
+	}
+
+	return api
+}
+
+// acquireTrace reserves a tracing slot, reporting false if the maximum number
+// of concurrent traces is already being served.
+func (api *API) acquireTrace() bool {
+	if api.traces == nil {
+		return true
+	}
+	select {
+	case api.traces <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// releaseTrace frees a tracing slot reserved by acquireTrace.
+func (api *API) releaseTrace() {
+	if api.traces != nil {
+		<-api.traces
+	}
 }
 
 type chainContext struct {
@@ -300,12 +337,18 @@ func (api *API) TraceChain(ctx context.Context, start, end rpc.BlockNumber, conf
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
 
+	if !api.acquireTrace() {
+		return nil, errTooManyTraces
+	}
+
 	sub := notifier.CreateSubscription()
 
 	// nolint : contextcheck
 	resCh := api.traceChain(from, to, config, notifier.Closed())
 
 	go func() {
+		defer api.releaseTrace()
+
 		for result := range resCh {
 			_ = notifier.Notify(sub.ID, result)
 		}
@@ -644,6 +687,11 @@ func prepareCallMessage(msg core.Message) statefull.Callmsg {
 // IntermediateRoots executes a block (bad- or canon- or side-), and returns a list
 // of intermediate roots: the stateroot after each transaction.
 func (api *API) IntermediateRoots(ctx context.Context, hash common.Hash, config *TraceConfig) ([]common.Hash, error) {
+	if !api.acquireTrace() {
+		return nil, errTooManyTraces
+	}
+	defer api.releaseTrace()
+
 	if config == nil {
 		config = &TraceConfig{
 			BorTraceEnabled: defaultBorTraceEnabled,
@@ -766,6 +814,11 @@ func (api *API) StandardTraceBadBlockToFile(ctx context.Context, hash common.Has
 // One thread runs along and executes txs without tracing enabled to generate their prestate.
 // Worker threads take the tasks and the prestate and trace them.
 func (api *API) traceBlock(ctx context.Context, block *types.Block, config *TraceConfig) ([]*txTraceResult, error) {
+	if !api.acquireTrace() {
+		return nil, errTooManyTraces
+	}
+	defer api.releaseTrace()
+
 	if config == nil {
 		config = &TraceConfig{
 			BorTraceEnabled: defaultBorTraceEnabled,
@@ -1009,6 +1062,11 @@ txloop:
 // and traces either a full block or an individual transaction. The return value will
 // be one filename per transaction traced.
 func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block, config *StdTraceConfig) ([]string, error) {
+	if !api.acquireTrace() {
+		return nil, errTooManyTraces
+	}
+	defer api.releaseTrace()
+
 	if config == nil {
 		config = &StdTraceConfig{
 			BorTraceEnabled: defaultBorTraceEnabled,
@@ -1174,6 +1232,11 @@ func (api *API) containsTx(ctx context.Context, block *types.Block, hash common.
 // TraceTransaction returns the structured logs created during the execution of EVM
 // and returns them as a JSON object.
 func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *TraceConfig) (interface{}, error) {
+	if !api.acquireTrace() {
+		return nil, errTooManyTraces
+	}
+	defer api.releaseTrace()
+
 	if config == nil {
 		config = &TraceConfig{
 			BorTraceEnabled: defaultBorTraceEnabled,
@@ -1237,6 +1300,11 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 // created during the execution of EVM if the given transaction was added on
 // top of the provided block and returns them as a JSON object.
 func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceCallConfig) (interface{}, error) {
+	if !api.acquireTrace() {
+		return nil, errTooManyTraces
+	}
+	defer api.releaseTrace()
+
 	// Try to retrieve the specified block
 	var (
 		err   error
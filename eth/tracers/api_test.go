@@ -1044,3 +1044,44 @@ func TestTraceChain(t *testing.T) {
 		}
 	}
 }
+
+// limitedBackend is a tracing backend restricting the concurrent traces.
+type limitedBackend struct {
+	Backend
+	limit int
+}
+
+func (b *limitedBackend) MaxConcurrentTraces() int { return b.limit }
+
+func TestMaxConcurrentTraces(t *testing.T) {
+	t.Parallel()
+
+	api := NewAPI(&limitedBackend{limit: 2})
+
+	for i := 0; i < 2; i++ {
+		if !api.acquireTrace() {
+			t.Fatalf("trace %d rejected below the limit", i)
+		}
+	}
+	// All slots are taken, further traces must be rejected before touching the backend
+	if _, err := api.TraceTransaction(context.Background(), common.Hash{}, nil); !errors.Is(err, errTooManyTraces) {
+		t.Fatalf("error mismatch: have %v, want %v", err, errTooManyTraces)
+	}
+
+	if _, err := api.TraceCall(context.Background(), ethapi.TransactionArgs{}, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil); !errors.Is(err, errTooManyTraces) {
+		t.Fatalf("error mismatch: have %v, want %v", err, errTooManyTraces)
+	}
+
+	api.releaseTrace()
+
+	if !api.acquireTrace() {
+		t.Fatalf("trace rejected after a slot was released")
+	}
+	// Backends without a limit never reject traces
+	unlimited := NewAPI(&limitedBackend{})
+	for i := 0; i < 16; i++ {
+		if !unlimited.acquireTrace() {
+			t.Fatalf("trace %d rejected without a limit", i)
+		}
+	}
+}
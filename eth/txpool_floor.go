@@ -0,0 +1,103 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// txPoolFloorTimeout is the maximum time allowed for the gas price oracle to
+// come up with a new txpool floor.
+const txPoolFloorTimeout = 10 * time.Second
+
+// startTxPoolFloorUpdater periodically derives the txpool minimum gas price from
+// the configured percentile of recent block tips, keeping the mempool aligned
+// with the network wide gas floor. It's a no-op unless a percentile is set.
+func (s *Ethereum) startTxPoolFloorUpdater() {
+	if s.config.TxPoolFloorPercentile <= 0 || s.config.TxPoolFloorInterval <= 0 {
+		return
+	}
+
+	params := s.config.GPO
+	params.Percentile = s.config.TxPoolFloorPercentile
+
+	if params.Default == nil {
+		params.Default = s.config.Miner.GasPrice
+	}
+
+	oracle := gasprice.NewOracle(s.APIBackend, params)
+
+	log.Info("Started dynamic txpool gas floor", "percentile", params.Percentile, "interval", s.config.TxPoolFloorInterval)
+
+	go func() {
+		ticker := time.NewTicker(s.config.TxPoolFloorInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.updateTxPoolFloor(oracle)
+
+			case <-s.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// updateTxPoolFloor raises or lowers the txpool minimum gas price to the tip
+// suggested by the oracle, never going below the configured miner gas price.
+func (s *Ethereum) updateTxPoolFloor(oracle *gasprice.Oracle) {
+	ctx, cancel := context.WithTimeout(context.Background(), txPoolFloorTimeout)
+	defer cancel()
+
+	tip, err := oracle.SuggestTipCap(ctx)
+	if err != nil {
+		log.Debug("Failed to derive txpool gas floor", "err", err)
+		return
+	}
+
+	floor := txPoolFloor(tip, s.config.Miner.GasPrice)
+
+	s.lock.Lock()
+	if s.gasPrice != nil && s.gasPrice.Cmp(floor) == 0 {
+		s.lock.Unlock()
+		return
+	}
+
+	old := s.gasPrice
+	s.gasPrice = floor
+	s.lock.Unlock()
+
+	s.txPool.SetGasPrice(floor)
+
+	log.Info("Updated txpool gas floor", "old", old, "new", floor)
+}
+
+// txPoolFloor returns the suggested gas price, bounded below by the minimum.
+func txPoolFloor(suggested, minimum *big.Int) *big.Int {
+	if minimum != nil && suggested.Cmp(minimum) < 0 {
+		return new(big.Int).Set(minimum)
+	}
+
+	return new(big.Int).Set(suggested)
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestTxPoolFloor(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		suggested *big.Int
+		minimum   *big.Int
+		expected  *big.Int
+	}{
+		{"above minimum", big.NewInt(30), big.NewInt(10), big.NewInt(30)},
+		{"below minimum", big.NewInt(5), big.NewInt(10), big.NewInt(10)},
+		{"no minimum", big.NewInt(5), nil, big.NewInt(5)},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if floor := txPoolFloor(tc.suggested, tc.minimum); floor.Cmp(tc.expected) != 0 {
+				t.Fatalf("floor mismatch: have %v, want %v", floor, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// errTxPoolReconcileThrottled is returned if a reconciliation of the
+	// transaction pool is requested too frequently.
+	errTxPoolReconcileThrottled = errors.New("transaction pool reconciled too recently")
+
+	// txPoolReconcileInterval is the minimum time between two on demand
+	// transaction pool reconciliations.
+	txPoolReconcileInterval = time.Minute
+)
+
+// reconcileTxPoolOnCheckpointMismatch resets the transaction pool whenever a
+// sync fails on a whitelisted checkpoint mismatch, as the downloader may have
+// rewound the chain in the meantime, leaving the pool with transactions that
+// are now invalid or need to be included again.
+func (s *Ethereum) reconcileTxPoolOnCheckpointMismatch() {
+	events := s.eventMux.Subscribe(downloader.StartEvent{}, downloader.FailedEvent{})
+	defer events.Unsubscribe()
+
+	var syncStart *types.Header
+
+	for {
+		select {
+		case ev := <-events.Chan():
+			if ev == nil {
+				return
+			}
+
+			switch ev := ev.Data.(type) {
+			case downloader.StartEvent:
+				syncStart = s.blockchain.CurrentBlock()
+
+			case downloader.FailedEvent:
+				if errors.Is(ev.Err, whitelist.ErrCheckpointMismatch) {
+					s.ReconcileTxPool(syncStart)
+				}
+			}
+
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// ReconcileTxPool resets the transaction pool against the current head block
+// and returns it. If the head before a rewind of the chain is given, the
+// transactions of the rewound blocks are reinjected into the pool.
+func (s *Ethereum) ReconcileTxPool(oldHead *types.Header) *types.Header {
+	head := s.blockchain.CurrentBlock()
+	s.txPool.Reconcile(oldHead, head)
+
+	pending, queued := s.txPool.Stats()
+	log.Info("Reconciled transaction pool", "number", head.Number, "hash", head.Hash(), "pending", pending, "queued", queued)
+
+	return head
+}
+
+// ReconcileTxPoolOnDemand resets the transaction pool against the current head
+// block on behalf of an operator and returns it. It can be called at most once
+// per txPoolReconcileInterval, as revalidating the whole pool is expensive.
+func (s *Ethereum) ReconcileTxPoolOnDemand() (*types.Header, error) {
+	s.lock.Lock()
+	if since := time.Since(s.lastTxPoolReconcile); since < txPoolReconcileInterval {
+		s.lock.Unlock()
+		return nil, fmt.Errorf("%w, retry in %v", errTxPoolReconcileThrottled, (txPoolReconcileInterval - since).Round(time.Second))
+	}
+	s.lastTxPoolReconcile = time.Now()
+	s.lock.Unlock()
+
+	return s.ReconcileTxPool(nil), nil
+}
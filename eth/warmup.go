@@ -0,0 +1,90 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// stateWarmupGauge counts the trie nodes loaded into the clean cache by the
+// post-sync state warmup.
+var stateWarmupGauge = metrics.NewRegisteredGauge("eth/warmup/entries", nil)
+
+// warmStateAfterSync waits for the first sync cycle to complete and warms the
+// trie clean cache with the state touched by the latest block, so the first
+// eth_call and eth_estimateGas requests don't have to load it from disk.
+//
+// Similarly to the miner, this is a one shot update loop: it stops listening to
+// the downloader events as soon as a sync completed.
+func (s *Ethereum) warmStateAfterSync() {
+	events := s.eventMux.Subscribe(downloader.DoneEvent{})
+	defer events.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-events.Chan():
+			if ev == nil {
+				return
+			}
+
+			done, ok := ev.Data.(downloader.DoneEvent)
+			if !ok || done.Latest == nil {
+				continue
+			}
+
+			if block := s.blockchain.GetBlock(done.Latest.Hash(), done.Latest.Number.Uint64()); block != nil {
+				s.warmState(block)
+			}
+
+			return
+
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// warmState prefetches the state touched by the given block into the trie clean
+// cache, reporting the number of cache entries it added.
+func (s *Ethereum) warmState(block *types.Block) {
+	triedb := s.blockchain.TrieDB()
+	before := triedb.CleanEntries()
+
+	if err := s.blockchain.PrefetchBlock(block); err != nil {
+		log.Warn("Failed to warm state after sync", "number", block.Number(), "hash", block.Hash(), "err", err)
+		return
+	}
+
+	var warmed uint64
+	if after := triedb.CleanEntries(); after > before {
+		warmed = after - before
+	}
+
+	s.warmedStateEntries.Store(warmed)
+	stateWarmupGauge.Update(int64(warmed))
+
+	log.Info("Warmed state after sync", "number", block.Number(), "hash", block.Hash(), "txs", len(block.Transactions()), "entries", warmed)
+}
+
+// WarmedStateEntries returns the number of trie nodes loaded into the clean cache
+// by the post-sync state warmup, approximated by the growth of the cache.
+func (s *Ethereum) WarmedStateEntries() uint64 {
+	return s.warmedStateEntries.Load()
+}
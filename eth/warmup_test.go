@@ -0,0 +1,102 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that a completed sync warms the clean cache with the state touched by
+// the latest block and reports the number of warmed entries.
+func TestWarmStateAfterSync(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{testAddr: {Balance: big.NewInt(params.Ether)}},
+		}
+		_, bs, _ = core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 3, func(i int, b *core.BlockGen) {
+			if i == 2 {
+				tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(testAddr), common.Address{0x02}, big.NewInt(1), params.TxGas, b.BaseFee(), nil), types.HomesteadSigner{}, testKey)
+				b.AddTx(tx)
+			}
+		})
+	)
+
+	// Import the chain and stop it to flush the recent states to disk, so that
+	// a restarted chain has to load them into its clean cache.
+	cacheConfig := *core.DefaultCacheConfig
+	cacheConfig.SnapshotLimit = 0
+
+	chain, _ := core.NewBlockChain(db, &cacheConfig, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	if _, err := chain.InsertChain(bs); err != nil {
+		t.Fatalf("failed to import blocks: %v", err)
+	}
+
+	chain.Stop()
+
+	chain, _ = core.NewBlockChain(db, &cacheConfig, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	defer chain.Stop()
+
+	eth := &Ethereum{
+		blockchain: chain,
+		eventMux:   new(event.TypeMux),
+		closeCh:    make(chan struct{}),
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		eth.warmStateAfterSync()
+		close(done)
+	}()
+
+	// The warmup may not be subscribed yet, keep posting until it finishes
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	timeout := time.After(5 * time.Second)
+
+	for finished := false; !finished; {
+		select {
+		case <-ticker.C:
+			eth.eventMux.Post(downloader.DoneEvent{Latest: bs[2].Header()})
+		case <-done:
+			finished = true
+		case <-timeout:
+			t.Fatalf("state warmup didn't finish after sync")
+		}
+	}
+
+	if eth.WarmedStateEntries() == 0 {
+		t.Fatalf("no warmed state entries reported")
+	}
+}
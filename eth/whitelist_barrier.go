@@ -0,0 +1,55 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// markWhitelistReady releases the startup barrier, either because a checkpoint
+// got whitelisted or because there's nothing to wait for.
+func (s *Ethereum) markWhitelistReady() {
+	s.whitelistReadyOnce.Do(func() { close(s.whitelistReady) })
+}
+
+// waitWhitelistBarrier blocks until the first checkpoint got whitelisted, so a
+// read replica opening its RPC endpoints after the services doesn't serve an
+// unverified head. On timeout, startup proceeds with a warning. It's a no-op
+// unless WhitelistRPCBarrier is set.
+func (s *Ethereum) waitWhitelistBarrier() {
+	timeout := s.config.WhitelistRPCBarrier
+	if timeout <= 0 {
+		return
+	}
+
+	log.Info("Waiting for the first checkpoint whitelisting before serving RPC", "timeout", timeout)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-s.whitelistReady:
+		log.Info("Checkpoint whitelisted, serving RPC")
+
+	case <-timer.C:
+		log.Warn("Timed out waiting for the first checkpoint whitelisting, serving RPC from an unverified head", "timeout", timeout)
+
+	case <-s.closeCh:
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+)
+
+// Tests that the startup barrier is released by the first successful checkpoint
+// whitelisting, and otherwise by its timeout.
+func TestWaitWhitelistBarrier(t *testing.T) {
+	t.Parallel()
+
+	newBarrier := func(timeout time.Duration) *Ethereum {
+		return &Ethereum{
+			config:         &ethconfig.Config{WhitelistRPCBarrier: timeout},
+			closeCh:        make(chan struct{}),
+			whitelistReady: make(chan struct{}),
+		}
+	}
+
+	waited := func(s *Ethereum) time.Duration {
+		start := time.Now()
+		s.waitWhitelistBarrier()
+
+		return time.Since(start)
+	}
+
+	// A failed whitelisting must not release the barrier before the timeout
+	s := newBarrier(100 * time.Millisecond)
+	s.recordWhitelistResult(errNoHeimdallCheckpoint)
+
+	if have := waited(s); have < 100*time.Millisecond {
+		t.Fatalf("barrier released early: have %v, want at least %v", have, 100*time.Millisecond)
+	}
+
+	// A successful one must release it right away, even if reported twice
+	s = newBarrier(time.Minute)
+	s.recordWhitelistResult(nil)
+	s.recordWhitelistResult(nil)
+
+	if have := waited(s); have > time.Second {
+		t.Fatalf("barrier not released: waited %v", have)
+	}
+
+	// No barrier configured, no waiting
+	if have := waited(newBarrier(0)); have > time.Second {
+		t.Fatalf("disabled barrier waited %v", have)
+	}
+}
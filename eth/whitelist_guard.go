@@ -0,0 +1,132 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// whitelistGuardInterval is the interval at which the checkpoint whitelisting
+// health is checked to pause or resume mining.
+var whitelistGuardInterval = 10 * time.Second
+
+// errWhitelistStale is returned when trying to resume mining while it's paused
+// for lack of recently whitelisted checkpoints.
+var errWhitelistStale = errors.New("mining paused, no checkpoint whitelisted recently")
+
+// startWhitelistMiningGuard periodically pauses the miner while no checkpoint
+// has been whitelisted within the configured staleness limit, as the node may
+// be building on a fork after losing heimdall, and resumes it on recovery. It's
+// a no-op unless a staleness limit is set.
+func (s *Ethereum) startWhitelistMiningGuard() {
+	if s.config.WhitelistStalenessLimit <= 0 || s.miner == nil {
+		return
+	}
+
+	log.Info("Pausing mining on stale checkpoint whitelisting", "limit", s.config.WhitelistStalenessLimit)
+
+	go func() {
+		ticker := time.NewTicker(whitelistGuardInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.checkWhitelistMiningGuard()
+
+			case <-s.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// whitelistStale reports whether no checkpoint has been whitelisted within the
+// configured staleness limit, measured from the start of the whitelist service
+// until the first success.
+func (s *Ethereum) whitelistStale() bool {
+	limit := s.config.WhitelistStalenessLimit
+	if limit <= 0 {
+		return false
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	last := s.lastWhitelist
+	if last.IsZero() {
+		last = s.whitelistStarted
+	}
+
+	if last.IsZero() {
+		return false
+	}
+
+	return time.Since(last) > limit
+}
+
+// checkWhitelistMiningGuard pauses a running miner if checkpoint whitelisting
+// went stale, and lifts the pause once it recovers.
+func (s *Ethereum) checkWhitelistMiningGuard() {
+	if s.miner == nil {
+		return
+	}
+
+	stale := s.whitelistStale()
+	held := s.miningHeld(miningHoldWhitelist)
+
+	switch {
+	case stale && held:
+		s.logWhitelistStale("Mining paused, no checkpoint whitelisted recently")
+
+	case stale && s.miner.Mining():
+		s.holdMining(miningHoldWhitelist)
+		s.logWhitelistStale("Pausing mining, no checkpoint whitelisted recently")
+
+	case !stale && held:
+		s.releaseMining(miningHoldWhitelist)
+
+		s.lock.RLock()
+		last := s.lastWhitelist
+		s.lock.RUnlock()
+
+		log.Info("Checkpoint whitelisting recovered, lifting the mining pause", "last", last)
+	}
+}
+
+// holdMiningIfWhitelistStale pauses mining until checkpoint whitelisting
+// recovers if it's stale. It's used to hold off a miner about to start.
+func (s *Ethereum) holdMiningIfWhitelistStale() {
+	if !s.whitelistStale() {
+		return
+	}
+
+	s.holdMining(miningHoldWhitelist)
+	s.logWhitelistStale("Pausing mining, no checkpoint whitelisted recently")
+}
+
+// logWhitelistStale logs the given message along with the checkpoint whitelisting
+// health at error level.
+func (s *Ethereum) logWhitelistStale(msg string) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	log.Error(msg, "last", s.lastWhitelist, "limit", s.config.WhitelistStalenessLimit, "err", s.lastWhitelistErr)
+}
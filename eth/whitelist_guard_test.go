@@ -0,0 +1,112 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+)
+
+func TestWhitelistStale(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	testCases := []struct {
+		name     string
+		limit    time.Duration
+		started  time.Time
+		last     time.Time
+		expected bool
+	}{
+		{"disabled", 0, now.Add(-time.Hour), time.Time{}, false},
+		{"service not started", time.Minute, time.Time{}, time.Time{}, false},
+		{"never whitelisted within limit", time.Minute, now, time.Time{}, false},
+		{"never whitelisted past limit", time.Minute, now.Add(-time.Hour), time.Time{}, true},
+		{"recently whitelisted", time.Minute, now.Add(-time.Hour), now, false},
+		{"whitelisted past limit", time.Minute, now.Add(-time.Hour), now.Add(-2 * time.Minute), true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &Ethereum{
+				config:           &ethconfig.Config{WhitelistStalenessLimit: tc.limit},
+				whitelistStarted: tc.started,
+				lastWhitelist:    tc.last,
+			}
+
+			if have := s.whitelistStale(); have != tc.expected {
+				t.Fatalf("staleness mismatch: have %v, want %v", have, tc.expected)
+			}
+		})
+	}
+}
+
+// Tests that the whitelist guard only lifts its own hold on the miner, leaving
+// a manual pause in place, and that manual resumes are refused while stale.
+func TestWhitelistGuardMiningHolds(t *testing.T) {
+	t.Parallel()
+
+	s := newMiningTestBackend(t, &ethconfig.Config{WhitelistStalenessLimit: time.Minute})
+	defer s.miner.Close()
+
+	s.whitelistStarted = time.Now().Add(-time.Hour)
+
+	// Starting on a stale whitelist keeps the miner paused
+	s.startMiner()
+
+	if !s.miningHeld(miningHoldWhitelist) || !s.miner.Paused() {
+		t.Fatalf("miner started on a stale whitelist")
+	}
+
+	if err := s.ResumeMining(); !errors.Is(err, errWhitelistStale) {
+		t.Fatalf("resume error mismatch: have %v, want %v", err, errWhitelistStale)
+	}
+
+	// A manual pause survives the whitelisting recovering
+	if err := s.PauseMining(); err != nil {
+		t.Fatalf("failed to pause mining: %v", err)
+	}
+
+	s.lock.Lock()
+	s.lastWhitelist = time.Now()
+	s.lock.Unlock()
+
+	s.checkWhitelistMiningGuard()
+
+	if s.miningHeld(miningHoldWhitelist) {
+		t.Fatalf("whitelist hold not lifted on recovery")
+	}
+
+	if !s.miner.Paused() {
+		t.Fatalf("manual pause lifted by the whitelist guard")
+	}
+
+	if err := s.ResumeMining(); err != nil {
+		t.Fatalf("failed to resume mining: %v", err)
+	}
+
+	if s.miner.Paused() {
+		t.Fatalf("mining still paused after all holds got lifted")
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// whitelistExportVersion is the version of the whitelist export format produced
+// by this node. Exports of later versions are rejected on import.
+const whitelistExportVersion = 1
+
+var (
+	// errWhitelistDisabled is returned when exporting or importing the whitelist
+	// of a node not validating chains against checkpoints.
+	errWhitelistDisabled = errors.New("checkpoint whitelisting disabled")
+
+	// errWhitelistVersion is returned when importing a whitelist export of an
+	// unsupported version.
+	errWhitelistVersion = errors.New("unsupported whitelist export version")
+
+	// errWhitelistInvalid is returned when importing malformed whitelist entries.
+	errWhitelistInvalid = errors.New("invalid whitelist entry")
+
+	// errWhitelistConflict is returned when importing a whitelist entry for a
+	// block whose local canonical hash differs.
+	errWhitelistConflict = errors.New("whitelist entry conflicts with the local chain")
+)
+
+// WhitelistEntry is the end block of a whitelisted checkpoint.
+type WhitelistEntry struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+}
+
+// WhitelistExport is the serializable checkpoint whitelist state of a node,
+// used to bootstrap another node before it reaches heimdall.
+type WhitelistExport struct {
+	Version     uint             `json:"version"`
+	Checkpoints []WhitelistEntry `json:"checkpoints"`
+}
+
+// ExportWhitelist returns the current checkpoint whitelist, sorted by number.
+func (s *Ethereum) ExportWhitelist() (*WhitelistExport, error) {
+	validator := s.handler.downloader.ChainValidator
+	if validator == nil {
+		return nil, errWhitelistDisabled
+	}
+
+	export := &WhitelistExport{Version: whitelistExportVersion, Checkpoints: []WhitelistEntry{}}
+
+	for number, hash := range validator.GetCheckpointWhitelist() {
+		export.Checkpoints = append(export.Checkpoints, WhitelistEntry{Number: hexutil.Uint64(number), Hash: hash})
+	}
+
+	sort.Slice(export.Checkpoints, func(i, j int) bool {
+		return export.Checkpoints[i].Number < export.Checkpoints[j].Number
+	})
+
+	return export, nil
+}
+
+// ImportWhitelist validates the checkpoints of a whitelist export and whitelists
+// them as if fetched from heimdall, requiring the latest one from new peers. The
+// export is rejected as a whole if any entry is malformed or conflicts with the
+// local chain. It returns the number of imported checkpoints.
+func (s *Ethereum) ImportWhitelist(export *WhitelistExport) (int, error) {
+	validator := s.handler.downloader.ChainValidator
+	if validator == nil {
+		return 0, errWhitelistDisabled
+	}
+
+	if export.Version == 0 || export.Version > whitelistExportVersion {
+		return 0, fmt.Errorf("%w: %d, want at most %d", errWhitelistVersion, export.Version, whitelistExportVersion)
+	}
+
+	entries := make([]WhitelistEntry, len(export.Checkpoints))
+	copy(entries, export.Checkpoints)
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Number < entries[j].Number
+	})
+
+	for i, entry := range entries {
+		number := uint64(entry.Number)
+
+		if entry.Hash == (common.Hash{}) {
+			return 0, fmt.Errorf("%w: empty hash for block #%d", errWhitelistInvalid, number)
+		}
+
+		if i > 0 && entries[i-1].Number == entry.Number {
+			return 0, fmt.Errorf("%w: duplicate block #%d", errWhitelistInvalid, number)
+		}
+
+		if local := rawdb.ReadCanonicalHash(s.chainDb, number); local != (common.Hash{}) && local != entry.Hash {
+			return 0, fmt.Errorf("%w: block #%d is %s locally, not %s", errWhitelistConflict, number, local, entry.Hash)
+		}
+	}
+
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	for _, entry := range entries {
+		validator.ProcessCheckpoint(uint64(entry.Number), entry.Hash)
+	}
+
+	last := entries[len(entries)-1]
+	s.handler.setCheckpointRequiredBlock(uint64(last.Number), last.Hash)
+
+	if s.config != nil && s.config.PersistWhitelist && !s.readOnly {
+		rawdb.WriteLastWhitelistedCheckpoint(s.chainDb, uint64(last.Number), last.Hash)
+	}
+
+	log.Info("Imported checkpoint whitelist", "count", len(entries), "number", uint64(last.Number), "hash", last.Hash)
+
+	return len(entries), nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+)
+
+// newWhitelistTestNode creates a node with an empty checkpoint whitelist.
+func newWhitelistTestNode() (*Ethereum, *whitelist.Service) {
+	service := whitelist.NewService(10)
+
+	return &Ethereum{
+		chainDb: rawdb.NewMemoryDatabase(),
+		handler: &handler{downloader: &downloader.Downloader{ChainValidator: service}},
+	}, service
+}
+
+func TestWhitelistExportImport(t *testing.T) {
+	t.Parallel()
+
+	source, sourceWhitelist := newWhitelistTestNode()
+	sourceWhitelist.ProcessCheckpoint(512, common.Hash{0x02})
+	sourceWhitelist.ProcessCheckpoint(256, common.Hash{0x01})
+
+	export, err := source.ExportWhitelist()
+	if err != nil {
+		t.Fatalf("failed to export whitelist: %v", err)
+	}
+
+	// Round trip the export through its serialized form
+	blob, err := json.Marshal(export)
+	if err != nil {
+		t.Fatalf("failed to encode whitelist: %v", err)
+	}
+
+	var decoded WhitelistExport
+	if err := json.Unmarshal(blob, &decoded); err != nil {
+		t.Fatalf("failed to decode whitelist: %v", err)
+	}
+
+	target, targetWhitelist := newWhitelistTestNode()
+
+	count, err := target.ImportWhitelist(&decoded)
+	if err != nil {
+		t.Fatalf("failed to import whitelist: %v", err)
+	}
+
+	if count != 2 {
+		t.Fatalf("imported count mismatch: have %d, want %d", count, 2)
+	}
+
+	expected := map[uint64]common.Hash{256: {0x01}, 512: {0x02}}
+	if have := targetWhitelist.GetCheckpointWhitelist(); !reflect.DeepEqual(have, expected) {
+		t.Fatalf("whitelist mismatch: have %v, want %v", have, expected)
+	}
+
+	required := map[uint64]common.Hash{512: {0x02}}
+	if have := target.handler.currentRequiredBlocks(); !reflect.DeepEqual(have, required) {
+		t.Fatalf("required blocks mismatch: have %v, want %v", have, required)
+	}
+}
+
+func TestWhitelistImportValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		export WhitelistExport
+		err    error
+	}{
+		{"missing version", WhitelistExport{Checkpoints: []WhitelistEntry{{Number: 256, Hash: common.Hash{0x01}}}}, errWhitelistVersion},
+		{"future version", WhitelistExport{Version: whitelistExportVersion + 1}, errWhitelistVersion},
+		{"empty hash", WhitelistExport{Version: 1, Checkpoints: []WhitelistEntry{{Number: 256}}}, errWhitelistInvalid},
+		{"duplicate", WhitelistExport{Version: 1, Checkpoints: []WhitelistEntry{{Number: 256, Hash: common.Hash{0x01}}, {Number: 256, Hash: common.Hash{0x02}}}}, errWhitelistInvalid},
+		{"local conflict", WhitelistExport{Version: 1, Checkpoints: []WhitelistEntry{{Number: 128, Hash: common.Hash{0x01}}}}, errWhitelistConflict},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			node, service := newWhitelistTestNode()
+			rawdb.WriteCanonicalHash(node.chainDb, common.Hash{0xff}, 128)
+
+			if _, err := node.ImportWhitelist(&tc.export); !errors.Is(err, tc.err) {
+				t.Fatalf("error mismatch: have %v, want %v", err, tc.err)
+			}
+
+			if have := service.GetCheckpointWhitelist(); len(have) != 0 {
+				t.Fatalf("rejected export whitelisted: %v", have)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/internal/cli/server/chains"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/enode"
@@ -52,6 +53,16 @@ type Config struct {
 	// RequiredBlocks is a list of required (block number, hash) pairs to accept
 	RequiredBlocks map[string]string `hcl:"eth.requiredblocks,optional" toml:"eth.requiredblocks,optional"`
 
+	// RequiredBlocksURL is an endpoint serving additional required blocks, refreshed periodically
+	RequiredBlocksURL string `hcl:"eth.requiredblocks.url,optional" toml:"eth.requiredblocks.url,optional"`
+
+	// RequiredBlocksSigner is the address which must sign the remote required blocks
+	RequiredBlocksSigner string `hcl:"eth.requiredblocks.signer,optional" toml:"eth.requiredblocks.signer,optional"`
+
+	// RequiredBlocksRefreshInterval is the interval at which the remote required blocks are refreshed
+	RequiredBlocksRefreshInterval    time.Duration `hcl:"-,optional" toml:"-"`
+	RequiredBlocksRefreshIntervalRaw string        `hcl:"eth.requiredblocks.interval,optional" toml:"eth.requiredblocks.interval,optional"`
+
 	// Verbosity is the level of the logs to put out
 	Verbosity int `hcl:"verbosity,optional" toml:"verbosity,optional"`
 
@@ -67,6 +78,15 @@ type Config struct {
 	// Ancient is the directory to store the state in
 	Ancient string `hcl:"ancient,optional" toml:"ancient,optional"`
 
+	// MinFreeDiskSpace is the free disk space in MB of the chain database below which block import and mining are paused
+	MinFreeDiskSpace uint64 `hcl:"datadir.minfreedisk,optional" toml:"datadir.minfreedisk,optional"`
+
+	// DBReadOnlyIfNewer opens a database written by a newer version read-only instead of failing
+	DBReadOnlyIfNewer bool `hcl:"db.readonlyifnewer,optional" toml:"db.readonlyifnewer,optional"`
+
+	// RequireGenesis fails startup on an empty database without a genesis, instead of writing the Ethereum main net one
+	RequireGenesis bool `hcl:"requiregenesis,optional" toml:"requiregenesis,optional"`
+
 	// KeyStoreDir is the directory to store keystores
 	KeyStoreDir string `hcl:"keystore,optional" toml:"keystore,optional"`
 
@@ -79,15 +99,27 @@ type Config struct {
 	// SyncMode selects the sync protocol
 	SyncMode string `hcl:"syncmode,optional" toml:"syncmode,optional"`
 
+	// SnapSyncStrict fails startup if snap sync is requested without a snapshot cache
+	SnapSyncStrict bool `hcl:"syncmode.snapstrict,optional" toml:"syncmode.snapstrict,optional"`
+
 	// GcMode selects the garbage collection mode for the trie
 	GcMode string `hcl:"gcmode,optional" toml:"gcmode,optional"`
 
+	// FailOnPruningRecoveryError fails the startup if an interrupted state pruning can't be recovered
+	FailOnPruningRecoveryError bool `hcl:"pruning.failonrecoveryerror,optional" toml:"pruning.failonrecoveryerror,optional"`
+
+	// MaxReorgDepth is the maximum number of canonical blocks a reorg may drop (0 = unlimited)
+	MaxReorgDepth uint64 `hcl:"maxreorgdepth,optional" toml:"maxreorgdepth,optional"`
+
 	// Snapshot enables the snapshot database mode
 	Snapshot bool `hcl:"snapshot,optional" toml:"snapshot,optional"`
 
 	// BorLogs enables bor log retrieval
 	BorLogs bool `hcl:"bor.logs,optional" toml:"bor.logs,optional"`
 
+	// Observer runs the node as a read-only replica with mining disabled
+	Observer bool `hcl:"observer,optional" toml:"observer,optional"`
+
 	// Ethstats is the address of the ethstats server to send telemetry
 	Ethstats string `hcl:"ethstats,optional" toml:"ethstats,optional"`
 
@@ -241,9 +273,43 @@ type HeimdallConfig struct {
 	// URL is the url of the heimdall server
 	URL string `hcl:"url,optional" toml:"url,optional"`
 
+	// FailoverURLs are additional heimdall servers used for checkpoint whitelisting when URL is unreachable
+	FailoverURLs []string `hcl:"failover-urls,optional" toml:"failover-urls,optional"`
+
+	// WhitelistGracePeriod is the period after startup during which whitelisted checkpoints aren't enforced on peers
+	WhitelistGracePeriod    time.Duration `hcl:"-,optional" toml:"-"`
+	WhitelistGracePeriodRaw string        `hcl:"whitelist-grace-period,optional" toml:"whitelist-grace-period,optional"`
+
+	// WhitelistFirstTimeout is the timeout of the first checkpoint whitelisting at startup (0 = same as the periodic runs)
+	WhitelistFirstTimeout    time.Duration `hcl:"-,optional" toml:"-"`
+	WhitelistFirstTimeoutRaw string        `hcl:"whitelist-first-timeout,optional" toml:"whitelist-first-timeout,optional"`
+
+	// WhitelistRPCBarrier delays serving RPC at startup until the first checkpoint is whitelisted, for at most this long (0 = disabled)
+	WhitelistRPCBarrier    time.Duration `hcl:"-,optional" toml:"-"`
+	WhitelistRPCBarrierRaw string        `hcl:"whitelist-rpc-barrier,optional" toml:"whitelist-rpc-barrier,optional"`
+
+	// WhitelistStalenessLimit pauses mining while no checkpoint has been whitelisted for longer than this (0 = disabled)
+	WhitelistStalenessLimit    time.Duration `hcl:"-,optional" toml:"-"`
+	WhitelistStalenessLimitRaw string        `hcl:"whitelist-staleness-limit,optional" toml:"whitelist-staleness-limit,optional"`
+
+	// WhitelistVerifyWorkers is the number of checkpoints fetched and verified concurrently when catching up the whitelist
+	WhitelistVerifyWorkers int `hcl:"whitelist-verify-workers,optional" toml:"whitelist-verify-workers,optional"`
+
+	// WhitelistRejectionsLimit is the number of recently rejected checkpoints kept for inspection via bor_recentCheckpointRejections
+	WhitelistRejectionsLimit int `hcl:"whitelist-rejections-limit,optional" toml:"whitelist-rejections-limit,optional"`
+
+	// PersistWhitelist stores the latest whitelisted checkpoint in the database and restores it at startup
+	PersistWhitelist bool `hcl:"persist-whitelist,optional" toml:"persist-whitelist,optional"`
+
 	// Without is used to disable remote heimdall during testing
 	Without bool `hcl:"bor.without,optional" toml:"bor.without,optional"`
 
+	// VerifyChainConfig cross-checks the genesis chain config against heimdall on startup
+	VerifyChainConfig bool `hcl:"verify-chain-config,optional" toml:"verify-chain-config,optional"`
+
+	// VerifyChainConfigStrict fails startup instead of warning if the chain config verification fails
+	VerifyChainConfigStrict bool `hcl:"verify-chain-config-strict,optional" toml:"verify-chain-config-strict,optional"`
+
 	// GRPCAddress is the address of the heimdall grpc server
 	GRPCAddress string `hcl:"grpc-address,optional" toml:"grpc-address,optional"`
 
@@ -292,6 +358,9 @@ type TxPoolConfig struct {
 	// lifetime is the maximum amount of time non-executable transaction are queued
 	LifeTime    time.Duration `hcl:"-,optional" toml:"-"`
 	LifeTimeRaw string        `hcl:"lifetime,optional" toml:"lifetime,optional"`
+
+	// AllowUnprotectedRemote allows non-EIP-155 transactions received from the network
+	AllowUnprotectedRemote bool `hcl:"allowunprotectedremote,optional" toml:"allowunprotectedremote,optional"`
 }
 
 type SealerConfig struct {
@@ -316,6 +385,31 @@ type SealerConfig struct {
 	RecommitRaw string        `hcl:"recommit,optional" toml:"recommit,optional"`
 
 	CommitInterruptFlag bool `hcl:"commitinterrupt,optional" toml:"commitinterrupt,optional"`
+
+	// MinPeers is the minimum number of connected peers to wait for before mining starts
+	MinPeers int `hcl:"minpeers,optional" toml:"minpeers,optional"`
+
+	// The maximum time to wait for the minimum number of peers before mining anyway
+	MinPeersTimeout    time.Duration `hcl:"-,optional" toml:"-"`
+	MinPeersTimeoutRaw string        `hcl:"minpeerstimeout,optional" toml:"minpeerstimeout,optional"`
+
+	// AutoEtherbase selects the first local account as etherbase if none is specified
+	AutoEtherbase bool `hcl:"autoetherbase,optional" toml:"autoetherbase,optional"`
+
+	// EtherbaseCandidates are the ordered fallback addresses tried when the etherbase can't be authorized
+	EtherbaseCandidates []string `hcl:"etherbase-candidates,optional" toml:"etherbase-candidates,optional"`
+
+	// DeterministicOrdering orders transactions by nonce and local first seen time instead of price
+	DeterministicOrdering bool `hcl:"deterministicordering,optional" toml:"deterministicordering,optional"`
+
+	// ReauthorizeEtherbase re-authorizes the consensus engine when the etherbase wallet reappears
+	ReauthorizeEtherbase bool `hcl:"reauthorize-etherbase,optional" toml:"reauthorize-etherbase,optional"`
+
+	// RemoteSigner is the endpoint of a remote signer (clef) sealing blocks instead of the local keystore
+	RemoteSigner string `hcl:"remote-signer,optional" toml:"remote-signer,optional"`
+
+	// SyncGuard decides whether mining started on an unsynced node proceeds (off), fails (refuse) or waits for the sync (wait)
+	SyncGuard string `hcl:"syncguard,optional" toml:"syncguard,optional"`
 }
 
 type JsonRPCConfig struct {
@@ -353,6 +447,30 @@ type JsonRPCConfig struct {
 
 	// EnablePersonal enables the deprecated personal namespace.
 	EnablePersonal bool `hcl:"enabledeprecatedpersonal,optional" toml:"enabledeprecatedpersonal,optional"`
+
+	// BackendAPIs limits the namespaces registered by the eth backend, regardless of the exposed modules
+	BackendAPIs []string `hcl:"backend-apis,optional" toml:"backend-apis,optional"`
+
+	// DebugMethods limits the methods registered in the debug namespace, e.g. debug_traceTransaction
+	DebugMethods []string `hcl:"debug-methods,optional" toml:"debug-methods,optional"`
+
+	// MaxConcurrentTraces is the maximum number of concurrent debug_trace* requests (0 = unlimited)
+	MaxConcurrentTraces int `hcl:"max-concurrent-traces,optional" toml:"max-concurrent-traces,optional"`
+
+	// LogsUnindexedLimit is the maximum number of blocks not yet indexed by the bloom indexer an eth_getLogs query may span (0 = unlimited)
+	LogsUnindexedLimit uint64 `hcl:"logs-unindexed-limit,optional" toml:"logs-unindexed-limit,optional"`
+
+	// BloomServiceThreads is the number of goroutines servicing bloom bit retrievals for all log filters
+	BloomServiceThreads int `hcl:"bloom-threads,optional" toml:"bloom-threads,optional"`
+
+	// BloomServiceQueue is the number of pending bloom bit retrievals before new ones are rejected as busy
+	BloomServiceQueue int `hcl:"bloom-queue,optional" toml:"bloom-queue,optional"`
+
+	// DisableBorFilterAPI disables the bor aware eth filter API
+	DisableBorFilterAPI bool `hcl:"disable-bor-filter-api,optional" toml:"disable-bor-filter-api,optional"`
+
+	// AdvertisedNetworkID is the network ID reported by net_version, while peering still uses the chain's one
+	AdvertisedNetworkID uint64 `hcl:"advertised-networkid,optional" toml:"advertised-networkid,optional"`
 }
 
 type AUTHConfig struct {
@@ -455,6 +573,20 @@ type GpoConfig struct {
 	// IgnorePrice is a lower bound gas price
 	IgnorePrice    *big.Int `hcl:"-,optional" toml:"-"`
 	IgnorePriceRaw string   `hcl:"ignoreprice,optional" toml:"ignoreprice,optional"`
+
+	// FloorPercentile is the percentile of recent block tips the txpool minimum
+	// gas price is derived from (0 = disabled)
+	FloorPercentile uint64 `hcl:"floorpercentile,optional" toml:"floorpercentile,optional"`
+
+	// FloorInterval is the interval at which the txpool minimum gas price is updated
+	FloorInterval    time.Duration `hcl:"-,optional" toml:"-"`
+	FloorIntervalRaw string        `hcl:"floorinterval,optional" toml:"floorinterval,optional"`
+
+	// ExcludeSystemTxs leaves bor state-sync transactions out of the fee history reward percentiles
+	ExcludeSystemTxs bool `hcl:"excludesystemtxs,optional" toml:"excludesystemtxs,optional"`
+
+	// IgnoreSenders are the sender addresses whose transactions the oracle leaves out of its samples
+	IgnoreSenders []string `hcl:"ignoresenders,optional" toml:"ignoresenders,optional"`
 }
 
 type TelemetryConfig struct {
@@ -464,6 +596,9 @@ type TelemetryConfig struct {
 	// Expensive enables expensive metrics
 	Expensive bool `hcl:"expensive,optional" toml:"expensive,optional"`
 
+	// BlockExecution enables per block execution time, transaction count and gas used histograms
+	BlockExecution bool `hcl:"blockexecution,optional" toml:"blockexecution,optional"`
+
 	// InfluxDB has the influxdb related settings
 	InfluxDB *InfluxDBConfig `hcl:"influx,block" toml:"influx,block"`
 
@@ -522,6 +657,12 @@ type CacheConfig struct {
 	// PercTrie is percentage of cache used for the trie
 	PercTrie uint64 `hcl:"trie,optional" toml:"trie,optional"`
 
+	// TrieMax is the maximum size of the trie cache in megabytes after archive mode redistribution, startup fails if exceeded (0 = unlimited)
+	TrieMax uint64 `hcl:"triemax,optional" toml:"triemax,optional"`
+
+	// PercArchiveSnapshot is percentage of the gc cache moved to the snapshot cache in archive mode
+	PercArchiveSnapshot uint64 `hcl:"archivesnapshot,optional" toml:"archivesnapshot,optional"`
+
 	// Journal is the disk journal directory for trie cache to survive node restarts
 	Journal string `hcl:"journal,optional" toml:"journal,optional"`
 
@@ -538,8 +679,12 @@ type CacheConfig struct {
 	// TxLookupLimit sets the maximum number of blocks from head whose tx indices are reserved.
 	TxLookupLimit uint64 `hcl:"txlookuplimit,optional" toml:"txlookuplimit,optional"`
 
-	// Number of block states to keep in memory (default = 128)
+	// Number of block states to keep in memory (default = 128, minimum = 64)
 	TriesInMemory uint64 `hcl:"triesinmemory,optional" toml:"triesinmemory,optional"`
+
+	// WarmState warms the trie cache with the state touched by the latest block once synced
+	WarmState bool `hcl:"warmstate,optional" toml:"warmstate,optional"`
+
 	// Time after which the Merkle Patricia Trie is stored to disc from memory
 	TrieTimeout    time.Duration `hcl:"-,optional" toml:"-"`
 	TrieTimeoutRaw string        `hcl:"timeout,optional" toml:"timeout,optional"`
@@ -580,18 +725,28 @@ type ParallelEVMConfig struct {
 	Enable bool `hcl:"enable,optional" toml:"enable,optional"`
 
 	SpeculativeProcesses int `hcl:"procs,optional" toml:"procs,optional"`
+
+	FatalOnDivergence bool `hcl:"fatalondivergence,optional" toml:"fatalondivergence,optional"`
+
+	MaxWorkers int `hcl:"maxworkers,optional" toml:"maxworkers,optional"`
+
+	ShadowVerify bool `hcl:"shadowverify,optional" toml:"shadowverify,optional"`
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		Chain:                   "mainnet",
-		Identity:                Hostname(),
-		RequiredBlocks:          map[string]string{},
-		Verbosity:               3,
-		LogLevel:                "",
-		EnablePreimageRecording: false,
-		DataDir:                 DefaultDataDir(),
-		Ancient:                 "",
+		Chain:                         "mainnet",
+		Identity:                      Hostname(),
+		RequiredBlocks:                map[string]string{},
+		RequiredBlocksRefreshInterval: 10 * time.Minute,
+		Verbosity:                     3,
+		LogLevel:                      "",
+		EnablePreimageRecording:       false,
+		DataDir:                       DefaultDataDir(),
+		Ancient:                       "",
+		MinFreeDiskSpace:              0,
+		DBReadOnlyIfNewer:             false,
+		RequireGenesis:                false,
 		Logging: &LoggingConfig{
 			Vmodule:   "",
 			Json:      false,
@@ -620,14 +775,25 @@ func DefaultConfig() *Config {
 			},
 		},
 		Heimdall: &HeimdallConfig{
-			URL:         "http://localhost:1317",
-			Without:     false,
-			GRPCAddress: "",
+			URL:                      "http://localhost:1317",
+			FailoverURLs:             []string{},
+			WhitelistGracePeriod:     0,
+			WhitelistFirstTimeout:    0,
+			WhitelistRPCBarrier:      0,
+			WhitelistStalenessLimit:  0,
+			WhitelistVerifyWorkers:   1,
+			WhitelistRejectionsLimit: 32,
+			PersistWhitelist:         false,
+			Without:                  false,
+			VerifyChainConfig:        false,
+			VerifyChainConfigStrict:  false,
+			GRPCAddress:              "",
 		},
 		SyncMode: "full",
 		GcMode:   "full",
 		Snapshot: true,
 		BorLogs:  false,
+		Observer: false,
 		TxPool: &TxPoolConfig{
 			Locals:       []string{},
 			NoLocals:     false,
@@ -642,13 +808,21 @@ func DefaultConfig() *Config {
 			LifeTime:     3 * time.Hour,
 		},
 		Sealer: &SealerConfig{
-			Enabled:             false,
-			Etherbase:           "",
-			GasCeil:             30_000_000,                  // geth's default
-			GasPrice:            big.NewInt(1 * params.GWei), // geth's default
-			ExtraData:           "",
-			Recommit:            125 * time.Second,
-			CommitInterruptFlag: true,
+			Enabled:               false,
+			Etherbase:             "",
+			GasCeil:               30_000_000,                  // geth's default
+			GasPrice:              big.NewInt(1 * params.GWei), // geth's default
+			ExtraData:             "",
+			Recommit:              125 * time.Second,
+			CommitInterruptFlag:   true,
+			MinPeers:              0,
+			MinPeersTimeout:       5 * time.Minute,
+			AutoEtherbase:         false,
+			EtherbaseCandidates:   []string{},
+			DeterministicOrdering: false,
+			ReauthorizeEtherbase:  false,
+			RemoteSigner:          "",
+			SyncGuard:             miner.SyncGuardOff,
 		},
 		Gpo: &GpoConfig{
 			Blocks:           20,
@@ -657,6 +831,10 @@ func DefaultConfig() *Config {
 			MaxBlockHistory:  1024,
 			MaxPrice:         gasprice.DefaultMaxPrice,
 			IgnorePrice:      gasprice.DefaultIgnorePrice,
+			FloorPercentile:  0,
+			FloorInterval:    time.Minute,
+			ExcludeSystemTxs: false,
+			IgnoreSenders:    []string{},
 		},
 		JsonRPC: &JsonRPCConfig{
 			IPCDisable:          false,
@@ -666,6 +844,14 @@ func DefaultConfig() *Config {
 			RPCEVMTimeout:       ethconfig.Defaults.RPCEVMTimeout,
 			AllowUnprotectedTxs: false,
 			EnablePersonal:      false,
+			BackendAPIs:         []string{},
+			DebugMethods:        []string{},
+			MaxConcurrentTraces: 0,
+			LogsUnindexedLimit:  0,
+			BloomServiceThreads: 16,
+			BloomServiceQueue:   256,
+			DisableBorFilterAPI: false,
+			AdvertisedNetworkID: 0,
 			Http: &APIConfig{
 				Enabled:                     false,
 				Port:                        8545,
@@ -708,6 +894,7 @@ func DefaultConfig() *Config {
 		Telemetry: &TelemetryConfig{
 			Enabled:               false,
 			Expensive:             false,
+			BlockExecution:        false,
 			PrometheusAddr:        "127.0.0.1:7071",
 			OpenCollectorEndpoint: "",
 			InfluxDB: &InfluxDBConfig{
@@ -724,19 +911,22 @@ func DefaultConfig() *Config {
 			},
 		},
 		Cache: &CacheConfig{
-			Cache:         1024, // geth's default (suitable for mumbai)
-			PercDatabase:  50,
-			PercTrie:      15,
-			PercGc:        25,
-			PercSnapshot:  10,
-			Journal:       "triecache",
-			Rejournal:     60 * time.Minute,
-			NoPrefetch:    false,
-			Preimages:     false,
-			TxLookupLimit: 2350000,
-			TriesInMemory: 128,
-			TrieTimeout:   60 * time.Minute,
-			FDLimit:       0,
+			Cache:               1024, // geth's default (suitable for mumbai)
+			PercDatabase:        50,
+			PercTrie:            15,
+			PercGc:              25,
+			PercSnapshot:        10,
+			TrieMax:             0,
+			PercArchiveSnapshot: 40,
+			Journal:             "triecache",
+			Rejournal:           60 * time.Minute,
+			NoPrefetch:          false,
+			Preimages:           false,
+			TxLookupLimit:       2350000,
+			TriesInMemory:       128,
+			WarmState:           false,
+			TrieTimeout:         60 * time.Minute,
+			FDLimit:             0,
 		},
 		Accounts: &AccountsConfig{
 			Unlock:              []string{},
@@ -765,6 +955,9 @@ func DefaultConfig() *Config {
 		ParallelEVM: &ParallelEVMConfig{
 			Enable:               true,
 			SpeculativeProcesses: 8,
+			FatalOnDivergence:    false,
+			MaxWorkers:           0,
+			ShadowVerify:         false,
 		},
 	}
 }
@@ -812,6 +1005,9 @@ func (c *Config) fillTimeDurations() error {
 	}{
 		{"jsonrpc.evmtimeout", &c.JsonRPC.RPCEVMTimeout, &c.JsonRPC.RPCEVMTimeoutRaw},
 		{"miner.recommit", &c.Sealer.Recommit, &c.Sealer.RecommitRaw},
+		{"miner.minpeerstimeout", &c.Sealer.MinPeersTimeout, &c.Sealer.MinPeersTimeoutRaw},
+		{"gpo.floorinterval", &c.Gpo.FloorInterval, &c.Gpo.FloorIntervalRaw},
+		{"eth.requiredblocks.interval", &c.RequiredBlocksRefreshInterval, &c.RequiredBlocksRefreshIntervalRaw},
 		{"jsonrpc.timeouts.read", &c.JsonRPC.HttpTimeout.ReadTimeout, &c.JsonRPC.HttpTimeout.ReadTimeoutRaw},
 		{"jsonrpc.timeouts.write", &c.JsonRPC.HttpTimeout.WriteTimeout, &c.JsonRPC.HttpTimeout.WriteTimeoutRaw},
 		{"jsonrpc.timeouts.idle", &c.JsonRPC.HttpTimeout.IdleTimeout, &c.JsonRPC.HttpTimeout.IdleTimeoutRaw},
@@ -822,6 +1018,10 @@ func (c *Config) fillTimeDurations() error {
 		{"cache.rejournal", &c.Cache.Rejournal, &c.Cache.RejournalRaw},
 		{"cache.timeout", &c.Cache.TrieTimeout, &c.Cache.TrieTimeoutRaw},
 		{"p2p.txarrivalwait", &c.P2P.TxArrivalWait, &c.P2P.TxArrivalWaitRaw},
+		{"heimdall.whitelist-grace-period", &c.Heimdall.WhitelistGracePeriod, &c.Heimdall.WhitelistGracePeriodRaw},
+		{"heimdall.whitelist-first-timeout", &c.Heimdall.WhitelistFirstTimeout, &c.Heimdall.WhitelistFirstTimeoutRaw},
+		{"heimdall.whitelist-rpc-barrier", &c.Heimdall.WhitelistRPCBarrier, &c.Heimdall.WhitelistRPCBarrierRaw},
+		{"heimdall.whitelist-staleness-limit", &c.Heimdall.WhitelistStalenessLimit, &c.Heimdall.WhitelistStalenessLimitRaw},
 	}
 
 	for _, x := range tds {
@@ -899,7 +1099,17 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	}
 
 	n.HeimdallURL = c.Heimdall.URL
+	n.HeimdallFailoverURLs = c.Heimdall.FailoverURLs
+	n.WhitelistGracePeriod = c.Heimdall.WhitelistGracePeriod
+	n.WhitelistFirstTimeout = c.Heimdall.WhitelistFirstTimeout
+	n.WhitelistRPCBarrier = c.Heimdall.WhitelistRPCBarrier
+	n.WhitelistStalenessLimit = c.Heimdall.WhitelistStalenessLimit
+	n.WhitelistVerifyWorkers = c.Heimdall.WhitelistVerifyWorkers
+	n.WhitelistRejectionsLimit = c.Heimdall.WhitelistRejectionsLimit
+	n.PersistWhitelist = c.Heimdall.PersistWhitelist
 	n.WithoutHeimdall = c.Heimdall.Without
+	n.VerifyChainConfigWithHeimdall = c.Heimdall.VerifyChainConfig
+	n.VerifyChainConfigStrict = c.Heimdall.VerifyChainConfigStrict
 	n.HeimdallgRPCAddress = c.Heimdall.GRPCAddress
 	n.RunHeimdall = c.Heimdall.RunHeimdall
 	n.RunHeimdallArgs = c.Heimdall.RunHeimdallArgs
@@ -919,6 +1129,18 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.GPO.MaxBlockHistory = uint64(c.Gpo.MaxBlockHistory)
 		n.GPO.MaxPrice = c.Gpo.MaxPrice
 		n.GPO.IgnorePrice = c.Gpo.IgnorePrice
+		n.GPO.ExcludeSystemTxs = c.Gpo.ExcludeSystemTxs
+
+		for _, sender := range c.Gpo.IgnoreSenders {
+			if !common.IsHexAddress(sender) {
+				return nil, fmt.Errorf("gpo ignored sender is not an address: %s", sender)
+			}
+
+			n.GPO.IgnoreSenders = append(n.GPO.IgnoreSenders, common.HexToAddress(sender))
+		}
+
+		n.TxPoolFloorPercentile = int(c.Gpo.FloorPercentile)
+		n.TxPoolFloorInterval = c.Gpo.FloorInterval
 	}
 
 	n.EnablePreimageRecording = c.EnablePreimageRecording
@@ -935,6 +1157,7 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.TxPool.AccountQueue = c.TxPool.AccountQueue
 		n.TxPool.GlobalQueue = c.TxPool.GlobalQueue
 		n.TxPool.Lifetime = c.TxPool.LifeTime
+		n.TxPool.AllowUnprotectedRemoteTxs = c.TxPool.AllowUnprotectedRemote
 	}
 
 	// miner options
@@ -944,6 +1167,19 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.Miner.GasCeil = c.Sealer.GasCeil
 		n.Miner.ExtraData = []byte(c.Sealer.ExtraData)
 		n.Miner.CommitInterruptFlag = c.Sealer.CommitInterruptFlag
+		n.Miner.MinPeers = c.Sealer.MinPeers
+		n.Miner.MinPeersTimeout = c.Sealer.MinPeersTimeout
+		n.Miner.AutoEtherbase = c.Sealer.AutoEtherbase
+		n.Miner.DeterministicOrdering = c.Sealer.DeterministicOrdering
+		n.Miner.ReauthorizeEtherbase = c.Sealer.ReauthorizeEtherbase
+		n.RemoteSigner = c.Sealer.RemoteSigner
+
+		switch c.Sealer.SyncGuard {
+		case miner.SyncGuardOff, miner.SyncGuardRefuse, miner.SyncGuardWait:
+			n.Miner.SyncGuard = c.Sealer.SyncGuard
+		default:
+			return nil, fmt.Errorf("sync guard must be one of %s, %s or %s: %s", miner.SyncGuardOff, miner.SyncGuardRefuse, miner.SyncGuardWait, c.Sealer.SyncGuard)
+		}
 
 		if etherbase := c.Sealer.Etherbase; etherbase != "" {
 			if !common.IsHexAddress(etherbase) {
@@ -952,6 +1188,14 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 
 			n.Miner.Etherbase = common.HexToAddress(etherbase)
 		}
+
+		for _, candidate := range c.Sealer.EtherbaseCandidates {
+			if !common.IsHexAddress(candidate) {
+				return nil, fmt.Errorf("etherbase candidate is not an address: %s", candidate)
+			}
+
+			n.Miner.EtherbaseCandidates = append(n.Miner.EtherbaseCandidates, common.HexToAddress(candidate))
+		}
 	}
 
 	// unlock accounts
@@ -1057,6 +1301,17 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		}
 	}
 
+	// Remote required blocks
+	if c.RequiredBlocksURL != "" {
+		if !common.IsHexAddress(c.RequiredBlocksSigner) {
+			return nil, fmt.Errorf("required blocks signer is not an address: %s", c.RequiredBlocksSigner)
+		}
+
+		n.RequiredBlocksURL = c.RequiredBlocksURL
+		n.RequiredBlocksSigner = common.HexToAddress(c.RequiredBlocksSigner)
+		n.RequiredBlocksRefreshInterval = c.RequiredBlocksRefreshInterval
+	}
+
 	// cache
 	{
 		cache := c.Cache.Cache
@@ -1090,11 +1345,17 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.SnapshotCache = calcPerc(c.Cache.PercSnapshot)
 		n.TrieCleanCache = calcPerc(c.Cache.PercTrie)
 		n.TrieDirtyCache = calcPerc(c.Cache.PercGc)
+		n.TrieCleanCacheMax = int(c.Cache.TrieMax)
+		if c.Cache.PercArchiveSnapshot > 100 {
+			return nil, fmt.Errorf("archive snapshot cache percentage must be between 0 and 100: %d", c.Cache.PercArchiveSnapshot)
+		}
+		n.NoPruningSnapshotShare = int(c.Cache.PercArchiveSnapshot)
 		n.NoPrefetch = c.Cache.NoPrefetch
 		n.Preimages = c.Cache.Preimages
 		n.TxLookupLimit = c.Cache.TxLookupLimit
 		n.TrieTimeout = c.Cache.TrieTimeout
 		n.TriesInMemory = c.Cache.TriesInMemory
+		n.WarmStateAfterSync = c.Cache.WarmState
 	}
 
 	n.RPCGasCap = c.JsonRPC.GasCap
@@ -1108,6 +1369,14 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 
 	n.RPCTxFeeCap = c.JsonRPC.TxFeeCap
 
+	n.RPCNamespaces = c.JsonRPC.BackendAPIs
+	n.MaxConcurrentTraces = c.JsonRPC.MaxConcurrentTraces
+	n.FilterUnindexedLimit = c.JsonRPC.LogsUnindexedLimit
+	n.BloomServiceThreads = c.JsonRPC.BloomServiceThreads
+	n.BloomServiceQueue = c.JsonRPC.BloomServiceQueue
+	n.DisableBorFilterAPI = c.JsonRPC.DisableBorFilterAPI
+	n.AdvertisedNetworkID = c.JsonRPC.AdvertisedNetworkID
+
 	// sync mode. It can either be "fast", "full" or "snap". We disable
 	// for now the "light" mode.
 	switch c.SyncMode {
@@ -1122,6 +1391,10 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		return nil, fmt.Errorf("sync mode '%s' not found", c.SyncMode)
 	}
 
+	n.SnapSyncStrict = c.SnapSyncStrict
+	n.FailOnPruningRecoveryError = c.FailOnPruningRecoveryError
+	n.MaxReorgDepth = c.MaxReorgDepth
+
 	// archive mode. It can either be "archive" or "full".
 	switch c.GcMode {
 	case "full":
@@ -1149,16 +1422,25 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	}
 
 	n.BorLogs = c.BorLogs
+	n.Observer = c.Observer
 	n.DatabaseHandles = dbHandles
 
 	n.ParallelEVM.Enable = c.ParallelEVM.Enable
 	n.ParallelEVM.SpeculativeProcesses = c.ParallelEVM.SpeculativeProcesses
+	n.ParallelEVM.FatalOnDivergence = c.ParallelEVM.FatalOnDivergence
+	n.ParallelEVM.MaxWorkers = c.ParallelEVM.MaxWorkers
+	n.ParallelEVM.ShadowVerify = c.ParallelEVM.ShadowVerify
+	n.BlockExecutionMetrics = c.Telemetry.BlockExecution
 	n.RPCReturnDataLimit = c.RPCReturnDataLimit
 
 	if c.Ancient != "" {
 		n.DatabaseFreezer = c.Ancient
 	}
 
+	n.MinFreeDiskSpace = c.MinFreeDiskSpace
+	n.DatabaseReadOnlyIfNewer = c.DBReadOnlyIfNewer
+	n.RequireGenesis = c.RequireGenesis
+
 	return &n, nil
 }
 
@@ -1285,6 +1567,7 @@ func (c *Config) buildNode() (*node.Config, error) {
 		IPCPath:               ipcPath,
 		AllowUnprotectedTxs:   c.JsonRPC.AllowUnprotectedTxs,
 		EnablePersonal:        c.JsonRPC.EnablePersonal,
+		DebugMethods:          c.JsonRPC.DebugMethods,
 		P2P: p2p.Config{
 			MaxPeers:        int(c.P2P.MaxPeers),
 			MaxPendingPeers: int(c.P2P.MaxPendPeers),
@@ -1315,6 +1598,7 @@ func (c *Config) buildNode() (*node.Config, error) {
 		WSJsonRPCExecutionPoolRequestTimeout:   c.JsonRPC.Ws.ExecutionPoolRequestTimeout,
 		HTTPJsonRPCExecutionPoolSize:           c.JsonRPC.Http.ExecutionPoolSize,
 		HTTPJsonRPCExecutionPoolRequestTimeout: c.JsonRPC.Http.ExecutionPoolRequestTimeout,
+		RPCAfterLifecycles:                     c.Heimdall.WhitelistRPCBarrier > 0,
 	}
 
 	if c.P2P.NetRestrict != "" {
@@ -53,6 +53,24 @@ func (c *Command) Flags() *flagset.Flagset {
 		Value:   &c.cliConfig.Ancient,
 		Default: c.cliConfig.Ancient,
 	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "datadir.minfreedisk",
+		Usage:   "Minimum free disk space in MB of the chain database, below which block import and mining are paused until space frees up (0 = disabled)",
+		Value:   &c.cliConfig.MinFreeDiskSpace,
+		Default: c.cliConfig.MinFreeDiskSpace,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "db.readonlyifnewer",
+		Usage:   "Open a database written by a newer version read-only for inspection instead of failing",
+		Value:   &c.cliConfig.DBReadOnlyIfNewer,
+		Default: c.cliConfig.DBReadOnlyIfNewer,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "requiregenesis",
+		Usage:   "Fail startup on an empty database without a genesis, instead of writing the Ethereum main net one",
+		Value:   &c.cliConfig.RequireGenesis,
+		Default: c.cliConfig.RequireGenesis,
+	})
 	f.StringFlag(&flagset.StringFlag{
 		Name:  "keystore",
 		Usage: "Path of the directory where keystores are located",
@@ -81,18 +99,54 @@ func (c *Command) Flags() *flagset.Flagset {
 		Value:   &c.cliConfig.SyncMode,
 		Default: c.cliConfig.SyncMode,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "syncmode.snapstrict",
+		Usage:   "Fail the startup if snap sync is requested without a snapshot cache, instead of enabling a minimal one",
+		Value:   &c.cliConfig.SnapSyncStrict,
+		Default: c.cliConfig.SnapSyncStrict,
+	})
 	f.StringFlag(&flagset.StringFlag{
 		Name:    "gcmode",
 		Usage:   `Blockchain garbage collection mode ("full", "archive")`,
 		Value:   &c.cliConfig.GcMode,
 		Default: c.cliConfig.GcMode,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "pruning.failonrecoveryerror",
+		Usage:   "Fail the startup instead of logging the error if an interrupted state pruning can't be recovered",
+		Value:   &c.cliConfig.FailOnPruningRecoveryError,
+		Default: c.cliConfig.FailOnPruningRecoveryError,
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "maxreorgdepth",
+		Usage:   "Maximum number of canonical blocks a reorg may drop before the node refuses it (0 = unlimited)",
+		Value:   &c.cliConfig.MaxReorgDepth,
+		Default: c.cliConfig.MaxReorgDepth,
+	})
 	f.MapStringFlag(&flagset.MapStringFlag{
 		Name:    "eth.requiredblocks",
 		Usage:   "Comma separated block number-to-hash mappings to require for peering (<number>=<hash>)",
 		Value:   &c.cliConfig.RequiredBlocks,
 		Default: c.cliConfig.RequiredBlocks,
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "eth.requiredblocks.url",
+		Usage:   "URL serving additional signed block number-to-hash mappings to require for peering, refreshed periodically",
+		Value:   &c.cliConfig.RequiredBlocksURL,
+		Default: c.cliConfig.RequiredBlocksURL,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "eth.requiredblocks.signer",
+		Usage:   "Address which must sign the required blocks served at eth.requiredblocks.url",
+		Value:   &c.cliConfig.RequiredBlocksSigner,
+		Default: c.cliConfig.RequiredBlocksSigner,
+	})
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "eth.requiredblocks.interval",
+		Usage:   "Interval at which the required blocks served at eth.requiredblocks.url are refreshed",
+		Value:   &c.cliConfig.RequiredBlocksRefreshInterval,
+		Default: c.cliConfig.RequiredBlocksRefreshInterval,
+	})
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "snapshot",
 		Usage:   `Enables the snapshot-database mode`,
@@ -105,6 +159,12 @@ func (c *Command) Flags() *flagset.Flagset {
 		Value:   &c.cliConfig.BorLogs,
 		Default: c.cliConfig.BorLogs,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "observer",
+		Usage:   "Run the node as a read-only replica, disabling the miner",
+		Value:   &c.cliConfig.Observer,
+		Default: c.cliConfig.Observer,
+	})
 
 	// logging related flags (log-level and verbosity is present above, it will be removed soon)
 	f.StringFlag(&flagset.StringFlag{
@@ -143,12 +203,72 @@ func (c *Command) Flags() *flagset.Flagset {
 		Value:   &c.cliConfig.Heimdall.URL,
 		Default: c.cliConfig.Heimdall.URL,
 	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "bor.heimdallfailover",
+		Usage:   "Comma separated URLs of additional Heimdall services used for checkpoint whitelisting when the primary one is unreachable",
+		Value:   &c.cliConfig.Heimdall.FailoverURLs,
+		Default: c.cliConfig.Heimdall.FailoverURLs,
+	})
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "bor.whitelistgraceperiod",
+		Usage:   "Period after startup during which whitelisted checkpoints are only logged and not enforced on peers",
+		Value:   &c.cliConfig.Heimdall.WhitelistGracePeriod,
+		Default: c.cliConfig.Heimdall.WhitelistGracePeriod,
+	})
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "bor.whitelistfirsttimeout",
+		Usage:   "Timeout of the first checkpoint whitelisting at startup, while Heimdall may be warming up (0 = same as the periodic runs)",
+		Value:   &c.cliConfig.Heimdall.WhitelistFirstTimeout,
+		Default: c.cliConfig.Heimdall.WhitelistFirstTimeout,
+	})
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "bor.whitelistrpcbarrier",
+		Usage:   "Delay serving RPC at startup until the first checkpoint is whitelisted, proceeding with a warning after this long (0 = disabled)",
+		Value:   &c.cliConfig.Heimdall.WhitelistRPCBarrier,
+		Default: c.cliConfig.Heimdall.WhitelistRPCBarrier,
+	})
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "bor.whiteliststalenesslimit",
+		Usage:   "Pause mining while no checkpoint has been whitelisted for longer than this, as the node may be on a fork (0 = disabled)",
+		Value:   &c.cliConfig.Heimdall.WhitelistStalenessLimit,
+		Default: c.cliConfig.Heimdall.WhitelistStalenessLimit,
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "bor.whitelistverifyworkers",
+		Usage:   "Number of checkpoints fetched and verified concurrently when catching up the checkpoint whitelist",
+		Value:   &c.cliConfig.Heimdall.WhitelistVerifyWorkers,
+		Default: c.cliConfig.Heimdall.WhitelistVerifyWorkers,
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "bor.whitelistrejectionslimit",
+		Usage:   "Number of recently rejected checkpoints kept along with the reason, readable via bor_recentCheckpointRejections (0 = none)",
+		Value:   &c.cliConfig.Heimdall.WhitelistRejectionsLimit,
+		Default: c.cliConfig.Heimdall.WhitelistRejectionsLimit,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "bor.persistwhitelist",
+		Usage:   "Persist the latest whitelisted checkpoint to the database and enforce it at startup, before Heimdall is reached",
+		Value:   &c.cliConfig.Heimdall.PersistWhitelist,
+		Default: c.cliConfig.Heimdall.PersistWhitelist,
+	})
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "bor.withoutheimdall",
 		Usage:   "Run without Heimdall service (for testing purpose)",
 		Value:   &c.cliConfig.Heimdall.Without,
 		Default: c.cliConfig.Heimdall.Without,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "bor.verifychainconfig",
+		Usage:   "Cross-check the genesis chain config (chain id, sprint and span alignment) against Heimdall on startup",
+		Value:   &c.cliConfig.Heimdall.VerifyChainConfig,
+		Default: c.cliConfig.Heimdall.VerifyChainConfig,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "bor.verifychainconfigstrict",
+		Usage:   "Fail startup instead of warning if the chain config doesn't match Heimdall",
+		Value:   &c.cliConfig.Heimdall.VerifyChainConfigStrict,
+		Default: c.cliConfig.Heimdall.VerifyChainConfigStrict,
+	})
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "bor.devfakeauthor",
 		Usage:   "Run miner without validator set authorization [dev mode] : Use with '--bor.withoutheimdall'",
@@ -258,6 +378,13 @@ func (c *Command) Flags() *flagset.Flagset {
 		Default: c.cliConfig.TxPool.LifeTime,
 		Group:   "Transaction Pool",
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "txpool.allowunprotectedremote",
+		Usage:   "Allow unprotected (non EIP155 signed) transactions received from the network, independently of rpc.allow-unprotected-txs",
+		Value:   &c.cliConfig.TxPool.AllowUnprotectedRemote,
+		Default: c.cliConfig.TxPool.AllowUnprotectedRemote,
+		Group:   "Transaction Pool",
+	})
 
 	// sealer options
 	f.BoolFlag(&flagset.BoolFlag{
@@ -309,6 +436,62 @@ func (c *Command) Flags() *flagset.Flagset {
 		Default: c.cliConfig.Sealer.CommitInterruptFlag,
 		Group:   "Sealer",
 	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "miner.minpeers",
+		Usage:   "Minimum number of connected peers to wait for before mining starts (0 = don't wait)",
+		Value:   &c.cliConfig.Sealer.MinPeers,
+		Default: c.cliConfig.Sealer.MinPeers,
+		Group:   "Sealer",
+	})
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "miner.minpeerstimeout",
+		Usage:   "Maximum time to wait for the minimum number of peers before mining anyway",
+		Value:   &c.cliConfig.Sealer.MinPeersTimeout,
+		Default: c.cliConfig.Sealer.MinPeersTimeout,
+		Group:   "Sealer",
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "miner.autoetherbase",
+		Usage:   "Use the first local account as etherbase if none is specified (not recommended)",
+		Value:   &c.cliConfig.Sealer.AutoEtherbase,
+		Default: c.cliConfig.Sealer.AutoEtherbase,
+		Group:   "Sealer",
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "miner.etherbasecandidates",
+		Usage:   "Comma separated fallback addresses tried in order if the etherbase account is unavailable locally",
+		Value:   &c.cliConfig.Sealer.EtherbaseCandidates,
+		Default: c.cliConfig.Sealer.EtherbaseCandidates,
+		Group:   "Sealer",
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "miner.deterministicordering",
+		Usage:   "Order transactions in mined blocks by nonce and local first seen time instead of price",
+		Value:   &c.cliConfig.Sealer.DeterministicOrdering,
+		Default: c.cliConfig.Sealer.DeterministicOrdering,
+		Group:   "Sealer",
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "miner.reauthorizeetherbase",
+		Usage:   "Re-authorize the consensus engine when the etherbase wallet is re-added or reopened",
+		Value:   &c.cliConfig.Sealer.ReauthorizeEtherbase,
+		Default: c.cliConfig.Sealer.ReauthorizeEtherbase,
+		Group:   "Sealer",
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "miner.remotesigner",
+		Usage:   "Endpoint of a remote signer (clef) sealing blocks instead of the local keystore",
+		Value:   &c.cliConfig.Sealer.RemoteSigner,
+		Default: c.cliConfig.Sealer.RemoteSigner,
+		Group:   "Sealer",
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "miner.syncguard",
+		Usage:   "Behaviour when mining is started while the node isn't synced: off (mine anyway), refuse or wait (until synced)",
+		Value:   &c.cliConfig.Sealer.SyncGuard,
+		Default: c.cliConfig.Sealer.SyncGuard,
+		Group:   "Sealer",
+	})
 
 	// ethstats
 	f.StringFlag(&flagset.StringFlag{
@@ -355,6 +538,30 @@ func (c *Command) Flags() *flagset.Flagset {
 		Value:   c.cliConfig.Gpo.IgnorePrice,
 		Default: c.cliConfig.Gpo.IgnorePrice,
 	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "gpo.floorpercentile",
+		Usage:   "Derive the txpool minimum gas price from the given percentile of recent block tips (0 = disabled)",
+		Value:   &c.cliConfig.Gpo.FloorPercentile,
+		Default: c.cliConfig.Gpo.FloorPercentile,
+	})
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "gpo.floorinterval",
+		Usage:   "Interval at which the txpool minimum gas price is derived from recent blocks",
+		Value:   &c.cliConfig.Gpo.FloorInterval,
+		Default: c.cliConfig.Gpo.FloorInterval,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "gpo.excludesystemtxs",
+		Usage:   "Leave bor state-sync transactions out of the eth_feeHistory reward percentiles",
+		Value:   &c.cliConfig.Gpo.ExcludeSystemTxs,
+		Default: c.cliConfig.Gpo.ExcludeSystemTxs,
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "gpo.ignoresenders",
+		Usage:   "Comma separated sender addresses whose transactions gpo will ignore",
+		Value:   &c.cliConfig.Gpo.IgnoreSenders,
+		Default: c.cliConfig.Gpo.IgnoreSenders,
+	})
 
 	// cache options
 	f.Uint64Flag(&flagset.Uint64Flag{
@@ -406,6 +613,20 @@ func (c *Command) Flags() *flagset.Flagset {
 		Default: c.cliConfig.Cache.PercSnapshot,
 		Group:   "Cache",
 	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "cache.triemax",
+		Usage:   "Maximum megabytes of trie cache once the gc allowance is redistributed in archive mode, startup fails if exceeded (0 = unlimited)",
+		Value:   &c.cliConfig.Cache.TrieMax,
+		Default: c.cliConfig.Cache.TrieMax,
+		Group:   "Cache",
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "cache.archivesnapshot",
+		Usage:   "Percentage (0-100) of the gc allowance moved to snapshot caching in archive mode, the rest goes to trie caching (0 = 40)",
+		Value:   &c.cliConfig.Cache.PercArchiveSnapshot,
+		Default: c.cliConfig.Cache.PercArchiveSnapshot,
+		Group:   "Cache",
+	})
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "cache.noprefetch",
 		Usage:   "Disable heuristic state prefetch during block import (less CPU and disk IO, more time waiting for data)",
@@ -422,7 +643,7 @@ func (c *Command) Flags() *flagset.Flagset {
 	})
 	f.Uint64Flag(&flagset.Uint64Flag{
 		Name:    "cache.triesinmemory",
-		Usage:   "Number of block states (tries) to keep in memory (default = 128)",
+		Usage:   "Number of block states (tries) to keep in memory (default = 128, minimum = 64)",
 		Value:   &c.cliConfig.Cache.TriesInMemory,
 		Default: c.cliConfig.Cache.TriesInMemory,
 		Group:   "Cache",
@@ -434,6 +655,13 @@ func (c *Command) Flags() *flagset.Flagset {
 		Default: c.cliConfig.Cache.TxLookupLimit,
 		Group:   "Cache",
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "cache.warmstate",
+		Usage:   "Warm the trie cache with the state touched by the latest block once synced, speeding up the first calls",
+		Value:   &c.cliConfig.Cache.WarmState,
+		Default: c.cliConfig.Cache.WarmState,
+		Group:   "Cache",
+	})
 	f.IntFlag(&flagset.IntFlag{
 		Name:    "fdlimit",
 		Usage:   "Raise the open file descriptor resource limit (default = system fd limit)",
@@ -478,6 +706,62 @@ func (c *Command) Flags() *flagset.Flagset {
 		Default: c.cliConfig.JsonRPC.EnablePersonal,
 		Group:   "JsonRPC",
 	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "rpc.backendapis",
+		Usage:   "Comma separated API namespaces registered by the eth backend, regardless of the exposed modules (default = all)",
+		Value:   &c.cliConfig.JsonRPC.BackendAPIs,
+		Default: c.cliConfig.JsonRPC.BackendAPIs,
+		Group:   "JsonRPC",
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "rpc.debugmethods",
+		Usage:   "Comma separated methods registered in the debug namespace, e.g. debug_traceTransaction (default = all)",
+		Value:   &c.cliConfig.JsonRPC.DebugMethods,
+		Default: c.cliConfig.JsonRPC.DebugMethods,
+		Group:   "JsonRPC",
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "rpc.maxconcurrenttraces",
+		Usage:   "Maximum number of debug_trace* requests served concurrently, further ones are rejected (0 = unlimited)",
+		Value:   &c.cliConfig.JsonRPC.MaxConcurrentTraces,
+		Default: c.cliConfig.JsonRPC.MaxConcurrentTraces,
+		Group:   "JsonRPC",
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "rpc.logsunindexedlimit",
+		Usage:   "Maximum number of blocks not yet covered by the bloom indexer an eth_getLogs query may span, wider ones are rejected with a suggested range (0 = unlimited)",
+		Value:   &c.cliConfig.JsonRPC.LogsUnindexedLimit,
+		Default: c.cliConfig.JsonRPC.LogsUnindexedLimit,
+		Group:   "JsonRPC",
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "rpc.bloomthreads",
+		Usage:   "Number of goroutines servicing bloom bit retrievals for all eth_getLogs queries and log filters",
+		Value:   &c.cliConfig.JsonRPC.BloomServiceThreads,
+		Default: c.cliConfig.JsonRPC.BloomServiceThreads,
+		Group:   "JsonRPC",
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "rpc.bloomqueue",
+		Usage:   "Number of bloom bit retrievals waiting for a servicing goroutine, further ones are rejected as busy",
+		Value:   &c.cliConfig.JsonRPC.BloomServiceQueue,
+		Default: c.cliConfig.JsonRPC.BloomServiceQueue,
+		Group:   "JsonRPC",
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "rpc.disableborfilterapi",
+		Usage:   "Disables the bor aware eth filter API",
+		Value:   &c.cliConfig.JsonRPC.DisableBorFilterAPI,
+		Default: c.cliConfig.JsonRPC.DisableBorFilterAPI,
+		Group:   "JsonRPC",
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "rpc.advertisednetworkid",
+		Usage:   "Network ID reported by net_version, while peering still uses the chain's one (0 = chain network ID)",
+		Value:   &c.cliConfig.JsonRPC.AdvertisedNetworkID,
+		Default: c.cliConfig.JsonRPC.AdvertisedNetworkID,
+		Group:   "JsonRPC",
+	})
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "ipcdisable",
 		Usage:   "Disable the IPC-RPC server",
@@ -768,6 +1052,13 @@ func (c *Command) Flags() *flagset.Flagset {
 		Default: c.cliConfig.Telemetry.Expensive,
 		Group:   "Telemetry",
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "metrics.blockexecution",
+		Usage:   "Enable block execution time, transaction count and gas used histograms, split by serial or parallel processor",
+		Value:   &c.cliConfig.Telemetry.BlockExecution,
+		Default: c.cliConfig.Telemetry.BlockExecution,
+		Group:   "Telemetry",
+	})
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "metrics.influxdb",
 		Usage:   "Enable metrics export/push to an external InfluxDB database (v1)",
@@ -925,6 +1216,24 @@ func (c *Command) Flags() *flagset.Flagset {
 		Value:   &c.cliConfig.ParallelEVM.SpeculativeProcesses,
 		Default: c.cliConfig.ParallelEVM.SpeculativeProcesses,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "parallelevm.fatalondivergence",
+		Usage:   "Fail the block import instead of re-executing serially if Block STM produces an invalid state",
+		Value:   &c.cliConfig.ParallelEVM.FatalOnDivergence,
+		Default: c.cliConfig.ParallelEVM.FatalOnDivergence,
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "parallelevm.maxworkers",
+		Usage:   "Maximum number of execution workers in Block STM, including the speculative ones (0 = unlimited)",
+		Value:   &c.cliConfig.ParallelEVM.MaxWorkers,
+		Default: c.cliConfig.ParallelEVM.MaxWorkers,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "parallelevm.shadowverify",
+		Usage:   "Also execute every block serially and report any mismatch with the Block STM results",
+		Value:   &c.cliConfig.ParallelEVM.ShadowVerify,
+		Default: c.cliConfig.ParallelEVM.ShadowVerify,
+	})
 	f.Uint64Flag(&flagset.Uint64Flag{
 		Name:    "dev.gaslimit",
 		Usage:   "Initial block gas limit",
@@ -201,8 +201,8 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 
 		srv.backend = backend
 
-		// authorize only if mining or in developer mode
-		if config.Sealer.Enabled || config.Developer.Enabled {
+		// authorize only if mining or in developer mode, observers never mine
+		if (config.Sealer.Enabled || config.Developer.Enabled) && !config.Observer {
 			// get the etherbase
 			eb, err := srv.backend.Etherbase()
 			if err != nil {
@@ -233,8 +233,9 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 				authorized = true
 			}
 
-			// Authorize the bor consensus (if chosen) to sign using wallet signer
-			if bor, ok := srv.backend.Engine().(*bor.Bor); ok {
+			// Authorize the bor consensus (if chosen) to sign using wallet signer,
+			// unless a remote signer authorizes it when mining starts
+			if bor, ok := srv.backend.Engine().(*bor.Bor); ok && ethCfg.RemoteSigner == "" {
 				wallet, err := accountManager.Find(accounts.Account{Address: eb})
 				if wallet == nil || err != nil {
 					log.Error("Etherbase account unavailable locally", "err", err)
@@ -272,7 +273,7 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 	}
 
 	// sealing (if enabled) or in dev mode
-	if config.Sealer.Enabled || config.Developer.Enabled {
+	if (config.Sealer.Enabled || config.Developer.Enabled) && !config.Observer {
 		if err := srv.backend.StartMining(1); err != nil {
 			return nil, err
 		}
@@ -2333,6 +2333,39 @@ func (s *TransactionAPI) PendingTransactions() ([]*RPCTransaction, error) {
 	return transactions, nil
 }
 
+// AccountPoolTransactions contains the transactions of a single sender in the
+// transaction pool, in nonce order.
+type AccountPoolTransactions struct {
+	Pending []*RPCTransaction `json:"pending"`
+	Queued  []*RPCTransaction `json:"queued,omitempty"`
+}
+
+// PendingTransactionsByAccount returns the executable transactions of the given
+// sender in the transaction pool, along with the non-executable ones if queued
+// is set, both in nonce order.
+func (s *TransactionAPI) PendingTransactionsByAccount(address common.Address, queued *bool) *AccountPoolTransactions {
+	pending, queue := s.b.TxPoolContentFrom(address)
+	curHeader := s.b.CurrentHeader()
+
+	result := &AccountPoolTransactions{
+		Pending: make([]*RPCTransaction, 0, len(pending)),
+	}
+
+	for _, tx := range pending {
+		result.Pending = append(result.Pending, NewRPCPendingTransaction(tx, curHeader, s.b.ChainConfig()))
+	}
+
+	if queued != nil && *queued {
+		result.Queued = make([]*RPCTransaction, 0, len(queue))
+
+		for _, tx := range queue {
+			result.Queued = append(result.Queued, NewRPCPendingTransaction(tx, curHeader, s.b.ChainConfig()))
+		}
+	}
+
+	return result
+}
+
 // Resend accepts an existing transaction and a new gas price and limit. It will remove
 // the given transaction from the pool and reinsert it with the new gas price and limit.
 // nolint:gocognit
@@ -2554,8 +2587,8 @@ func (api *DebugAPI) ChaindbCompact() error {
 }
 
 // SetHead rewinds the head of the blockchain to a previous block.
-func (api *DebugAPI) SetHead(number hexutil.Uint64) {
-	api.b.SetHead(uint64(number))
+func (api *DebugAPI) SetHead(number hexutil.Uint64) error {
+	return api.b.SetHead(uint64(number))
 }
 
 // GetCheckpointWhitelist retrieves the current checkpoint whitelist
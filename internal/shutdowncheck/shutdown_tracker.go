@@ -17,6 +17,7 @@
 package shutdowncheck
 
 import (
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -31,6 +32,9 @@ import (
 type ShutdownTracker struct {
 	db     ethdb.Database
 	stopCh chan struct{}
+
+	lock             sync.RWMutex
+	uncleanShutdowns []time.Time // Ungraceful shutdowns found by MarkStartup
 }
 
 // NewShutdownTracker creates a new ShutdownTracker instance and has
@@ -53,14 +57,34 @@ func (t *ShutdownTracker) MarkStartup() {
 			log.Warn("Old unclean shutdowns found", "count", discards)
 		}
 
+		history := make([]time.Time, 0, len(uncleanShutdowns))
+
 		for _, tstamp := range uncleanShutdowns {
 			t := time.Unix(int64(tstamp), 0)
 			log.Warn("Unclean shutdown detected", "booted", t,
 				"age", common.PrettyAge(t))
+
+			history = append(history, t)
 		}
+
+		t.lock.Lock()
+		t.uncleanShutdowns = history
+		t.lock.Unlock()
 	}
 }
 
+// UncleanShutdowns returns the boot times of the previous runs that did not
+// shut down gracefully, as recorded by MarkStartup.
+func (t *ShutdownTracker) UncleanShutdowns() []time.Time {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	history := make([]time.Time, len(t.uncleanShutdowns))
+	copy(history, t.uncleanShutdowns)
+
+	return history
+}
+
 // Start runs an event loop that updates the current marker's timestamp every 5 minutes.
 func (t *ShutdownTracker) Start() {
 	go func() {
@@ -0,0 +1,72 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package shutdowncheck
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// Tests that the unclean shutdowns found on startup are kept for inspection,
+// while graceful shutdowns are left out.
+func TestUncleanShutdowns(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+
+	// A pristine database has no history
+	first := NewShutdownTracker(db)
+	first.MarkStartup()
+
+	if history := first.UncleanShutdowns(); len(history) != 0 {
+		t.Fatalf("history mismatch on first startup: have %v, want none", history)
+	}
+
+	// The first run never shut down, so the second one finds it
+	before := time.Now().Add(-time.Second)
+
+	second := NewShutdownTracker(db)
+	second.MarkStartup()
+
+	history := second.UncleanShutdowns()
+	if len(history) != 1 {
+		t.Fatalf("history length mismatch: have %d, want %d", len(history), 1)
+	}
+
+	if history[0].Before(before.Truncate(time.Second)) {
+		t.Fatalf("unclean shutdown boot time mismatch: have %v, want after %v", history[0], before)
+	}
+
+	// The returned history must not alias the tracker's own
+	history[0] = time.Time{}
+	if second.UncleanShutdowns()[0].IsZero() {
+		t.Fatalf("history modified through the returned slice")
+	}
+
+	// A graceful shutdown of the second run isn't reported by the third one
+	second.Start()
+	second.Stop()
+
+	third := NewShutdownTracker(db)
+	third.MarkStartup()
+
+	if history := third.UncleanShutdowns(); len(history) != 1 {
+		t.Fatalf("history length mismatch after graceful shutdown: have %d, want %d", len(history), 1)
+	}
+}
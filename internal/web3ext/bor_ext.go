@@ -48,18 +48,64 @@ web3._extend({
 		new web3._extend.Method({
 			name: 'getCurrentProposer',
 			call: 'bor_getCurrentProposer',
-			params: 0
+			params: 1,
+			inputFormatter: [null]
 		}),
 		new web3._extend.Method({
 			name: 'getCurrentValidators',
 			call: 'bor_getCurrentValidators',
-			params: 0
+			params: 1,
+			inputFormatter: [null]
 		}),
 		new web3._extend.Method({
 			name: 'getRootHash',
 			call: 'bor_getRootHash',
 			params: 2,
 		}),
+		new web3._extend.Method({
+			name: 'diagnostics',
+			call: 'bor_diagnostics',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'exportSnapshot',
+			call: 'bor_exportSnapshot',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'getStateSyncEvents',
+			call: 'bor_getStateSyncEvents',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'spanInfo',
+			call: 'bor_spanInfo',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'lastHeimdallCheckpoint',
+			call: 'bor_lastHeimdallCheckpoint',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'recentCheckpointRejections',
+			call: 'bor_recentCheckpointRejections',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getBlockByNumber',
+			call: 'bor_getBlockByNumber',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, function (val) { return !!val; }]
+		}),
+		new web3._extend.Method({
+			name: 'getBlockByHash',
+			call: 'bor_getBlockByHash',
+			params: 2,
+			inputFormatter: [null, function (val) { return !!val; }]
+		}),
 	]
 });
 `
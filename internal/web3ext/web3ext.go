@@ -155,6 +155,78 @@ web3._extend({
 			call: 'admin_importChain',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'setNoPruning',
+			call: 'admin_setNoPruning',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'addTxPoolLocal',
+			call: 'admin_addTxPoolLocal',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'removeTxPoolLocal',
+			call: 'admin_removeTxPoolLocal',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'reconcileTxPoolLocals',
+			call: 'admin_reconcileTxPoolLocals',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'setCheckpointEnforcement',
+			call: 'admin_setCheckpointEnforcement',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'checkpointEnforcement',
+			call: 'admin_checkpointEnforcement'
+		}),
+		new web3._extend.Method({
+			name: 'setSnapDiscovery',
+			call: 'admin_setSnapDiscovery',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'snapDiscovery',
+			call: 'admin_snapDiscovery'
+		}),
+		new web3._extend.Method({
+			name: 'setGasPriceOracleConfig',
+			call: 'admin_setGasPriceOracleConfig',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'exportWhitelist',
+			call: 'admin_exportWhitelist'
+		}),
+		new web3._extend.Method({
+			name: 'importWhitelist',
+			call: 'admin_importWhitelist',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'dnsDiscoveryStatus',
+			call: 'admin_dnsDiscoveryStatus'
+		}),
+		new web3._extend.Method({
+			name: 'peerProtocols',
+			call: 'admin_peerProtocols'
+		}),
+		new web3._extend.Method({
+			name: 'nodeENR',
+			call: 'admin_nodeENR'
+		}),
+		new web3._extend.Method({
+			name: 'diskStatus',
+			call: 'admin_diskStatus'
+		}),
+		new web3._extend.Method({
+			name: 'effectiveConfig',
+			call: 'admin_effectiveConfig'
+		}),
 		new web3._extend.Method({
 			name: 'sleepBlocks',
 			call: 'admin_sleepBlocks',
@@ -250,6 +322,52 @@ const DebugJs = `
 web3._extend({
 	property: 'debug',
 	methods: [
+		new web3._extend.Method({
+			name: 'trieCacheStats',
+			call: 'debug_trieCacheStats',
+			params: 0,
+		}),
+		new web3._extend.Method({
+			name: 'bloomIndexStatus',
+			call: 'debug_bloomIndexStatus',
+			params: 0,
+		}),
+		new web3._extend.Method({
+			name: 'dumpDiagnostics',
+			call: 'debug_dumpDiagnostics',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'forceBloomIndex',
+			call: 'debug_forceBloomIndex',
+			params: 0,
+		}),
+		new web3._extend.Method({
+			name: 'snapshotStatus',
+			call: 'debug_snapshotStatus',
+			params: 0,
+		}),
+		new web3._extend.Method({
+			name: 'regenerateSnapshot',
+			call: 'debug_regenerateSnapshot',
+			params: 0,
+		}),
+		new web3._extend.Method({
+			name: 'flushTrieCacheJournal',
+			call: 'debug_flushTrieCacheJournal',
+			params: 0,
+		}),
+		new web3._extend.Method({
+			name: 'verifyStateRoot',
+			call: 'debug_verifyStateRoot',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'shutdownHistory',
+			call: 'debug_shutdownHistory',
+			params: 0,
+		}),
 		new web3._extend.Method({
 			name: 'accountRange',
 			call: 'debug_accountRange',
@@ -568,6 +686,51 @@ web3._extend({
 			call: 'eth_chainId',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'reprocessGasPriceCache',
+			call: 'eth_reprocessGasPriceCache',
+			params: 0,
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
+		new web3._extend.Method({
+			name: 'suggestGasPriceBor',
+			call: 'eth_suggestGasPriceBor',
+			params: 0,
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
+		new web3._extend.Method({
+			name: 'gasPriceOracleConfig',
+			call: 'eth_gasPriceOracleConfig',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'borLogsEnabled',
+			call: 'eth_borLogsEnabled',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'mergeStatus',
+			call: 'eth_mergeStatus',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getBorBlockReceiptByNumber',
+			call: 'eth_getBorBlockReceiptByNumber',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'callAtSpan',
+			call: 'eth_callAtSpan',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputCallFormatter, web3._extend.utils.fromDecimal, null]
+		}),
+		new web3._extend.Method({
+			name: 'pendingTransactionsByAccount',
+			call: 'eth_pendingTransactionsByAccount',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'sign',
 			call: 'eth_sign',
@@ -707,6 +870,18 @@ web3._extend({
 			name: 'stop',
 			call: 'miner_stop'
 		}),
+		new web3._extend.Method({
+			name: 'pause',
+			call: 'miner_pause'
+		}),
+		new web3._extend.Method({
+			name: 'resume',
+			call: 'miner_resume'
+		}),
+		new web3._extend.Method({
+			name: 'paused',
+			call: 'miner_paused'
+		}),
 		new web3._extend.Method({
 			name: 'setEtherbase',
 			call: 'miner_setEtherbase',
@@ -739,6 +914,10 @@ web3._extend({
 			name: 'getHashrate',
 			call: 'miner_getHashrate'
 		}),
+		new web3._extend.Method({
+			name: 'getPendingWork',
+			call: 'miner_getPendingWork'
+		}),
 	],
 	properties: []
 });
@@ -854,6 +1033,16 @@ web3._extend({
 			call: 'txpool_contentFrom',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'listLocals',
+			call: 'txpool_listLocals',
+			params: 0,
+		}),
+		new web3._extend.Method({
+			name: 'journalStatus',
+			call: 'txpool_journalStatus',
+			params: 0,
+		}),
 	]
 });
 `
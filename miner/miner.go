@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -56,9 +57,29 @@ type Config struct {
 	Noverify            bool           // Disable remote mining solution verification(only useful in ethash).
 	CommitInterruptFlag bool           // Interrupt commit when time is up ( default = true)
 
+	MinPeers        int           // Minimum number of connected peers to wait for before mining starts (0 = don't wait)
+	MinPeersTimeout time.Duration // The maximum time to wait for the minimum peer count before mining anyway
+
 	NewPayloadTimeout time.Duration // The maximum time allowance for creating a new payload
+
+	AutoEtherbase       bool             // Use the first local account as etherbase if none is specified
+	EtherbaseCandidates []common.Address // Ordered fallback etherbases tried if the signer of the configured one is missing
+
+	DeterministicOrdering bool // Order transactions by nonce and local first seen time instead of price
+
+	ReauthorizeEtherbase bool // Re-authorize the consensus engine when the etherbase wallet reappears
+
+	SyncGuard string // Behaviour when mining is started on an unsynced node (off, refuse or wait)
 }
 
+// Sync guard modes, deciding what happens when mining is started while the node
+// isn't synced.
+const (
+	SyncGuardOff    = "off"    // Start mining anyway
+	SyncGuardRefuse = "refuse" // Refuse to start mining
+	SyncGuardWait   = "wait"   // Start mining once the node is synced
+)
+
 // DefaultConfig contains default settings for miner.
 var DefaultConfig = Config{
 	GasCeil:  30000000,
@@ -83,6 +104,10 @@ type Miner struct {
 	stopCh  chan struct{}
 	worker  *worker
 
+	pauseCh  chan struct{}
+	resumeCh chan struct{}
+	paused   atomic.Bool // Whether block creation is paused, leaving the sealing engine set up
+
 	wg sync.WaitGroup
 }
 
@@ -95,6 +120,9 @@ func New(eth Backend, config *Config, chainConfig *params.ChainConfig, mux *even
 		startCh: make(chan struct{}),
 		stopCh:  make(chan struct{}),
 		worker:  newWorker(config, chainConfig, engine, eth, mux, isLocalBlock, true),
+
+		pauseCh:  make(chan struct{}),
+		resumeCh: make(chan struct{}),
 	}
 	miner.wg.Add(1)
 
@@ -146,13 +174,13 @@ func (miner *Miner) update() {
 			case downloader.FailedEvent:
 				canStart = true
 
-				if shouldStart {
+				if shouldStart && !miner.paused.Load() {
 					miner.worker.start()
 				}
 			case downloader.DoneEvent:
 				canStart = true
 
-				if shouldStart {
+				if shouldStart && !miner.paused.Load() {
 					miner.worker.start()
 				}
 				// Stop reacting to downloader events
@@ -168,6 +196,12 @@ func (miner *Miner) update() {
 			shouldStart = false
 
 			miner.worker.stop()
+		case <-miner.pauseCh:
+			miner.worker.stop()
+		case <-miner.resumeCh:
+			if shouldStart && canStart {
+				miner.worker.start()
+			}
 		case <-miner.exitCh:
 			miner.worker.close()
 			return
@@ -175,12 +209,43 @@ func (miner *Miner) update() {
 	}
 }
 
+// Start begins block creation, overriding any pause in place.
 func (miner *Miner) Start() {
-	miner.startCh <- struct{}{}
+	miner.paused.Store(false)
+	miner.send(miner.startCh)
 }
 
 func (miner *Miner) Stop() {
-	miner.stopCh <- struct{}{}
+	miner.send(miner.stopCh)
+}
+
+// Pause halts block creation without tearing down the mining setup, so that a
+// later Resume picks up where it left off. Unlike Stop, the miner keeps track of
+// whether it was asked to mine, and sync events won't restart it while paused.
+func (miner *Miner) Pause() {
+	miner.paused.Store(true)
+	miner.send(miner.pauseCh)
+}
+
+// Resume restarts block creation halted by Pause, if the miner was started and
+// isn't waiting for a sync to finish.
+func (miner *Miner) Resume() {
+	miner.paused.Store(false)
+	miner.send(miner.resumeCh)
+}
+
+// send delivers a request to the update loop, dropping it if the miner was
+// closed in the meantime rather than blocking forever.
+func (miner *Miner) send(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	case <-miner.exitCh:
+	}
+}
+
+// Paused reports whether block creation is currently paused.
+func (miner *Miner) Paused() bool {
+	return miner.paused.Load()
 }
 
 func (miner *Miner) Close() {
@@ -192,6 +192,71 @@ func TestStartStopMiner(t *testing.T) {
 	waitForMiningState(t, miner, false)
 }
 
+func TestPauseResumeMiner(t *testing.T) {
+	t.Parallel()
+
+	minerBor := NewBorDefaultMiner(t)
+	defer func() {
+		minerBor.Cleanup(false)
+		minerBor.Ctrl.Finish()
+	}()
+
+	miner := minerBor.Miner
+
+	miner.Start()
+	waitForMiningState(t, miner, true)
+
+	miner.Pause()
+	waitForMiningState(t, miner, false)
+
+	if !miner.Paused() {
+		t.Fatalf("Paused() == false, want true")
+	}
+
+	// Resuming shouldn't need a fresh Start
+	miner.Resume()
+	waitForMiningState(t, miner, true)
+
+	if miner.Paused() {
+		t.Fatalf("Paused() == true, want false")
+	}
+
+	miner.Stop()
+	waitForMiningState(t, miner, false)
+
+	// Resuming a stopped miner must not start it
+	miner.Pause()
+	miner.Resume()
+	waitForMiningState(t, miner, false)
+}
+
+func TestPausedMinerIgnoresDownloaderEvents(t *testing.T) {
+	t.Parallel()
+
+	minerBor := NewBorDefaultMiner(t)
+	defer func() {
+		minerBor.Cleanup(false)
+		minerBor.Ctrl.Finish()
+	}()
+
+	miner := minerBor.Miner
+	mux := minerBor.Mux
+
+	miner.Start()
+	waitForMiningState(t, miner, true)
+
+	miner.Pause()
+	waitForMiningState(t, miner, false)
+
+	// A finished sync shouldn't restart a paused miner
+	mux.Post(downloader.StartEvent{})
+	mux.Post(downloader.DoneEvent{})
+	waitForMiningState(t, miner, false)
+
+	miner.Resume()
+	waitForMiningState(t, miner, true)
+}
+
 func TestCloseMiner(t *testing.T) {
 	t.Parallel()
 
@@ -732,7 +732,7 @@ func (w *worker) mainLoop(ctx context.Context) {
 					baseFee = cmath.FromBig(w.current.header.BaseFee)
 				}
 
-				txset := types.NewTransactionsByPriceAndNonce(w.current.signer, txs, baseFee)
+				txset := w.newTransactionSet(w.current.signer, txs, baseFee)
 				tcount := w.current.tcount
 
 				//nolint:contextcheck
@@ -1039,6 +1039,17 @@ func (w *worker) commitTransaction(env *environment, tx *types.Transaction, inte
 	return receipt.Logs, nil
 }
 
+// newTransactionSet orders the given pending transactions for inclusion, by
+// price by default or by the time they were first seen by this node if
+// deterministic ordering is enabled.
+func (w *worker) newTransactionSet(signer types.Signer, txs map[common.Address]types.Transactions, baseFee *uint256.Int) *types.TransactionsByPriceAndNonce {
+	if w.config.DeterministicOrdering {
+		return types.NewTransactionsByNonceAndTime(signer, txs, baseFee)
+	}
+
+	return types.NewTransactionsByPriceAndNonce(signer, txs, baseFee)
+}
+
 //nolint:gocognit
 func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByPriceAndNonce, interrupt *atomic.Int32, interruptCtx context.Context) error {
 	gasLimit := env.header.GasLimit
@@ -1583,7 +1594,7 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 				baseFee = cmath.FromBig(env.header.BaseFee)
 			}
 
-			txs = types.NewTransactionsByPriceAndNonce(env.signer, localTxs, baseFee)
+			txs = w.newTransactionSet(env.signer, localTxs, baseFee)
 
 			tracing.SetAttributes(
 				span,
@@ -1611,7 +1622,7 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 				baseFee = cmath.FromBig(env.header.BaseFee)
 			}
 
-			txs = types.NewTransactionsByPriceAndNonce(env.signer, remoteTxs, baseFee)
+			txs = w.newTransactionSet(env.signer, remoteTxs, baseFee)
 
 			tracing.SetAttributes(
 				span,
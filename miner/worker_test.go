@@ -668,6 +668,105 @@ func testGetSealingWork(t *testing.T, chainConfig *params.ChainConfig, engine co
 	}
 }
 
+// nolint : paralleltest
+func TestTransactionOrderingByPrice(t *testing.T) {
+	testTransactionOrdering(t, false)
+}
+
+// nolint : paralleltest
+func TestTransactionOrderingDeterministic(t *testing.T) {
+	testTransactionOrdering(t, true)
+}
+
+// testTransactionOrdering seals a block with a cheap transaction seen before an
+// expensive one from another account, checking that they are ordered by price
+// by default and by first seen time with deterministic ordering.
+//
+//nolint:thelper
+func testTransactionOrdering(t *testing.T, deterministic bool) {
+	var (
+		engine = ethash.NewFaker()
+		signer = types.LatestSigner(ethashChainConfig)
+		b      = newTestWorkerBackend(t, ethashChainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+	)
+	defer engine.Close()
+
+	// Fund a second account, so that transactions of two senders compete
+	blocks, _ := core.GenerateChain(ethashChainConfig, b.chain.Genesis(), engine, b.DB, 1, func(i int, gen *core.BlockGen) {
+		gen.AddTx(types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    0,
+			To:       &testUserAddress,
+			Value:    big.NewInt(params.Ether),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(params.InitialBaseFee),
+		}))
+	})
+	if _, err := b.chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert funding block: %v", err)
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); b.txPool.Nonce(TestBankAddress) != 1; {
+		if time.Now().After(deadline) {
+			t.Fatal("transaction pool not reset to the funding block")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The cheap transaction is seen first, the expensive one afterwards
+	cheap := types.MustSignNewTx(testUserKey, signer, &types.LegacyTx{
+		Nonce:    0,
+		To:       &TestBankAddress,
+		Value:    big.NewInt(1),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(2 * params.InitialBaseFee),
+	})
+
+	time.Sleep(10 * time.Millisecond)
+
+	expensive := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+		Nonce:    1,
+		To:       &testUserAddress,
+		Value:    big.NewInt(1),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(3 * params.InitialBaseFee),
+	})
+
+	for i, err := range b.txPool.AddRemotesSync([]*types.Transaction{expensive, cheap}) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+
+	config := *testConfig
+	config.DeterministicOrdering = deterministic
+
+	//nolint:staticcheck
+	w := newWorker(&config, ethashChainConfig, engine, b, new(event.TypeMux), nil, false)
+	defer w.close()
+
+	block, _, err := w.getSealingBlock(b.chain.CurrentBlock().Hash(), uint64(time.Now().Unix()), TestBankAddress, common.Hash{}, nil, false)
+	if err != nil {
+		t.Fatalf("failed to seal block: %v", err)
+	}
+
+	want := []common.Hash{expensive.Hash(), cheap.Hash()}
+	if deterministic {
+		want = []common.Hash{cheap.Hash(), expensive.Hash()}
+	}
+
+	txs := block.Transactions()
+	if len(txs) != len(want) {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(txs), len(want))
+	}
+
+	for i, tx := range txs {
+		if tx.Hash() != want[i] {
+			t.Errorf("transaction %d mismatch: have %x, want %x", i, tx.Hash(), want[i])
+		}
+	}
+}
+
 // nolint : paralleltest
 // TestCommitInterruptExperimentBor tests the commit interrupt experiment for bor consensus by inducing an artificial delay at transaction level.
 func TestCommitInterruptExperimentBor(t *testing.T) {
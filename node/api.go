@@ -47,6 +47,32 @@ func (n *Node) apis() []rpc.API {
 	}
 }
 
+// restrictDebugMethods wraps the services of the debug namespace so that only
+// the listed methods, given with or without the debug_ prefix, are registered.
+// A nil or empty list of methods leaves the APIs untouched.
+func restrictDebugMethods(apis []rpc.API, methods []string) []rpc.API {
+	if len(methods) == 0 {
+		return apis
+	}
+
+	allowed := make([]string, 0, len(methods))
+	for _, method := range methods {
+		allowed = append(allowed, strings.TrimPrefix(method, "debug_"))
+	}
+
+	restricted := make([]rpc.API, 0, len(apis))
+
+	for _, api := range apis {
+		if api.Namespace == "debug" {
+			api.Service = rpc.RestrictMethods(api.Service, allowed)
+		}
+
+		restricted = append(restricted, api)
+	}
+
+	return restricted
+}
+
 // adminAPI is the collection of administrative API methods exposed over
 // both secure and unsecure RPC channels.
 type adminAPI struct {
@@ -18,6 +18,7 @@ package node
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"net"
 	"net/http"
@@ -377,6 +378,50 @@ func checkRPC(url string) bool {
 	return err == nil
 }
 
+// testDebugService is a debug namespace service registered by a lifecycle.
+type testDebugService struct{}
+
+func (s *testDebugService) TraceBlock() string { return "trace" }
+func (s *testDebugService) DumpBlock() string  { return "dump" }
+
+// Tests that the debug method allowlist applies to the debug service of the node
+// itself as well as to those registered by other services.
+func TestRestrictDebugMethods(t *testing.T) {
+	config := testNodeConfig()
+	config.DebugMethods = []string{"debug_traceBlock", "stacks"}
+
+	stack, err := New(config)
+	if err != nil {
+		t.Fatalf("failed to create protocol stack: %v", err)
+	}
+	defer stack.Close()
+
+	stack.RegisterAPIs([]rpc.API{{Namespace: "debug", Service: new(testDebugService)}})
+
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start protocol stack: %v", err)
+	}
+
+	client, err := stack.Attach()
+	if err != nil {
+		t.Fatalf("failed to attach to protocol stack: %v", err)
+	}
+	defer client.Close()
+
+	for _, method := range []string{"debug_traceBlock", "debug_stacks"} {
+		if err := client.Call(nil, method); err != nil {
+			t.Errorf("allowed method %s failed: %v", method, err)
+		}
+	}
+
+	for _, method := range []string{"debug_dumpBlock", "debug_writeMemProfile", "debug_startCPUProfile", "debug_setGCPercent"} {
+		var rpcErr rpc.Error
+		if err := client.Call(nil, method); !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != -32601 {
+			t.Errorf("method %s error mismatch: have %v, want method not found", method, err)
+		}
+	}
+}
+
 // string/int pointer helpers.
 func sp(s string) *string { return &s }
 func ip(i int) *int       { return &i }
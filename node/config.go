@@ -208,6 +208,11 @@ type Config struct {
 	// EnablePersonal enables the deprecated personal namespace.
 	EnablePersonal bool `toml:"-"`
 
+	// DebugMethods limits the methods registered in the debug namespace, by the
+	// node itself as well as by all services, to the listed ones, e.g.
+	// debug_traceTransaction (nil = all).
+	DebugMethods []string `toml:",omitempty"`
+
 	DBEngine string `toml:",omitempty"`
 
 	// Maximum number of messages in a batch
@@ -217,6 +222,10 @@ type Config struct {
 	WSJsonRPCExecutionPoolRequestTimeout   time.Duration `toml:",omitempty"`
 	HTTPJsonRPCExecutionPoolSize           uint64        `toml:",omitempty"`
 	HTTPJsonRPCExecutionPoolRequestTimeout time.Duration `toml:",omitempty"`
+
+	// RPCAfterLifecycles opens the RPC endpoints only once all lifecycles have
+	// started, so a lifecycle can hold back serving requests during startup.
+	RPCAfterLifecycles bool `toml:",omitempty"`
 }
 
 // IPCEndpoint resolves an IPC endpoint based on a configured value, taking into
@@ -123,7 +123,7 @@ func New(conf *Config) (*Node, error) {
 	node.inprocHandler.SetRPCBatchLimit(conf.RPCBatchLimit)
 
 	// Register built-in APIs.
-	node.rpcAPIs = append(node.rpcAPIs, node.apis()...)
+	node.rpcAPIs = append(node.rpcAPIs, restrictDebugMethods(node.apis(), conf.DebugMethods)...)
 
 	// Acquire the instance directory lock.
 	if err := node.openDataDir(); err != nil {
@@ -187,8 +187,14 @@ func (n *Node) Start() error {
 	}
 
 	n.state = runningState
-	// open networking and RPC endpoints
-	err := n.openEndpoints()
+	// open networking and RPC endpoints, deferring the latter if requested
+	var err error
+	if n.config.RPCAfterLifecycles {
+		err = n.openP2P()
+	} else {
+		err = n.openEndpoints()
+	}
+
 	lifecycles := make([]Lifecycle, len(n.lifecycles))
 	copy(lifecycles, n.lifecycles)
 	n.lock.Unlock()
@@ -208,6 +214,12 @@ func (n *Node) Start() error {
 
 		started = append(started, lifecycle)
 	}
+	// Open the deferred RPC endpoints once all lifecycles are up.
+	if err == nil && n.config.RPCAfterLifecycles {
+		n.lock.Lock()
+		err = n.startRPC()
+		n.lock.Unlock()
+	}
 	// Check if any lifecycle failed to start.
 	if err != nil {
 		n.stopServices(started)
@@ -285,10 +297,8 @@ func (n *Node) doClose(errs []error) error {
 // openEndpoints starts all network and RPC endpoints.
 func (n *Node) openEndpoints() error {
 	// start networking endpoints
-	n.log.Info("Starting peer-to-peer node", "instance", n.server.Name)
-
-	if err := n.server.Start(); err != nil {
-		return convertFileLockError(err)
+	if err := n.openP2P(); err != nil {
+		return err
 	}
 	// start RPC endpoints
 	err := n.startRPC()
@@ -300,6 +310,17 @@ func (n *Node) openEndpoints() error {
 	return err
 }
 
+// openP2P starts the peer-to-peer networking endpoints.
+func (n *Node) openP2P() error {
+	n.log.Info("Starting peer-to-peer node", "instance", n.server.Name)
+
+	if err := n.server.Start(); err != nil {
+		return convertFileLockError(err)
+	}
+
+	return nil
+}
+
 // containsLifecycle checks if 'lfs' contains 'l'.
 func containsLifecycle(lfs []Lifecycle, l Lifecycle) bool {
 	for _, obj := range lfs {
@@ -644,7 +665,7 @@ func (n *Node) RegisterAPIs(apis []rpc.API) {
 		panic("can't register APIs on running/stopped node")
 	}
 
-	n.rpcAPIs = append(n.rpcAPIs, apis...)
+	n.rpcAPIs = append(n.rpcAPIs, restrictDebugMethods(apis, n.config.DebugMethods)...)
 }
 
 // getAPIs return two sets of APIs, both the ones that do not require
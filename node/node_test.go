@@ -199,6 +199,45 @@ func TestNodeOpenDatabaseFromLifecycleStart(t *testing.T) {
 }
 
 // This test checks that OpenDatabase can be used from within a Lifecycle Stop method.
+// Tests that the RPC endpoints are only opened once all lifecycles started if
+// RPCAfterLifecycles is set.
+func TestNodeRPCAfterLifecycles(t *testing.T) {
+	config := testNodeConfig()
+	config.HTTPHost = "127.0.0.1"
+	config.RPCAfterLifecycles = true
+
+	stack, err := New(config)
+	if err != nil {
+		t.Fatalf("failed to create protocol stack: %v", err)
+	}
+	defer stack.Close()
+
+	serving := func() bool {
+		stack.http.mu.Lock()
+		defer stack.http.mu.Unlock()
+
+		return stack.http.listener != nil
+	}
+
+	var servingAtStart bool
+
+	stack.RegisterLifecycle(&InstrumentedService{
+		startHook: func() { servingAtStart = serving() },
+	})
+
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start protocol stack: %v", err)
+	}
+
+	if servingAtStart {
+		t.Fatalf("RPC served before the lifecycles started")
+	}
+
+	if !serving() {
+		t.Fatalf("RPC not served after the lifecycles started")
+	}
+}
+
 func TestNodeOpenDatabaseFromLifecycleStop(t *testing.T) {
 	stack, _ := New(testNodeConfig())
 	defer stack.Close()
@@ -51,6 +51,32 @@ func TestServerRegisterName(t *testing.T) {
 	}
 }
 
+func TestServerRegisterRestrictedName(t *testing.T) {
+	server := NewServer("test", 0, 0)
+
+	if err := server.RegisterName("test", RestrictMethods(new(testService), []string{"echo", "rets", "unknown"})); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	svc := server.services.services["test"]
+	if len(svc.callbacks) != 2 || svc.callbacks["echo"] == nil || svc.callbacks["rets"] == nil {
+		t.Fatalf("Expected only echo and rets callbacks, got %d", len(svc.callbacks))
+	}
+
+	if len(svc.subscriptions) != 0 {
+		t.Fatalf("Expected no subscriptions, got %d", len(svc.subscriptions))
+	}
+
+	// Restricting all the methods away registers nothing
+	if err := server.RegisterName("hidden", RestrictMethods(new(testService), nil)); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if _, ok := server.services.services["hidden"]; ok {
+		t.Fatalf("Expected no service for fully restricted receiver")
+	}
+}
+
 func TestServer(t *testing.T) {
 	files, err := os.ReadDir("testdata")
 	if err != nil {
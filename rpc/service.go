@@ -58,6 +58,11 @@ type callback struct {
 }
 
 func (r *serviceRegistry) registerName(name string, rcvr interface{}) error {
+	var allowed map[string]struct{}
+	if restricted, ok := rcvr.(*restrictedReceiver); ok {
+		rcvr, allowed = restricted.receiver, restricted.methods
+	}
+
 	rcvrVal := reflect.ValueOf(rcvr)
 	if name == "" {
 		return fmt.Errorf("no service name for type %s", rcvrVal.Type().String())
@@ -68,6 +73,19 @@ func (r *serviceRegistry) registerName(name string, rcvr interface{}) error {
 		return fmt.Errorf("service %T doesn't have any suitable methods/subscriptions to expose", rcvr)
 	}
 
+	if allowed != nil {
+		for method := range callbacks {
+			if _, ok := allowed[method]; !ok {
+				delete(callbacks, method)
+			}
+		}
+
+		// A restriction deliberately hiding every method isn't an error
+		if len(callbacks) == 0 {
+			return nil
+		}
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -96,6 +114,28 @@ func (r *serviceRegistry) registerName(name string, rcvr interface{}) error {
 	return nil
 }
 
+// restrictedReceiver wraps a service receiver, exposing only some of its methods.
+type restrictedReceiver struct {
+	receiver interface{}
+	methods  map[string]struct{}
+}
+
+// RestrictMethods wraps the given receiver so that only the listed methods are
+// registered, named as exposed over RPC without the namespace (e.g. traceBlock).
+// The other methods of the receiver, including subscriptions, are dropped.
+func RestrictMethods(receiver interface{}, methods []string) interface{} {
+	if restricted, ok := receiver.(*restrictedReceiver); ok {
+		receiver = restricted.receiver
+	}
+
+	allowed := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		allowed[method] = struct{}{}
+	}
+
+	return &restrictedReceiver{receiver: receiver, methods: allowed}
+}
+
 // callback returns the callback corresponding to the given RPC method name.
 func (r *serviceRegistry) callback(method string) *callback {
 	elem := strings.SplitN(method, serviceMethodSeparator, 2)
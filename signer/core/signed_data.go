@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"mime"
 
 	"github.com/ethereum/go-ethereum/accounts"
@@ -175,6 +176,29 @@ func (api *SignerAPI) determineSignatureFormat(ctx context.Context, contentType
 		// Clique uses V on the form 0 or 1
 		useEthereumV = false
 		req = &SignDataRequest{ContentType: mediaType, Rawdata: cliqueRlp, Messages: messages, Hash: sighash}
+	case apitypes.ApplicationBor.Mime:
+		// Bor headers are sent in their seal hash form, i.e. without the signature
+		borData, err := fromHex(data)
+		if err != nil {
+			return nil, useEthereumV, err
+		}
+
+		header := new(borSealHeader)
+		if err := rlp.DecodeBytes(borData, header); err != nil {
+			return nil, useEthereumV, err
+		}
+
+		sighash := crypto.Keccak256(borData)
+		messages := []*apitypes.NameValueType{
+			{
+				Name:  "Bor header",
+				Typ:   "bor",
+				Value: fmt.Sprintf("bor header %d [%#x]", header.Number, sighash),
+			},
+		}
+		// Bor uses V on the form 0 or 1
+		useEthereumV = false
+		req = &SignDataRequest{ContentType: mediaType, Rawdata: borData, Messages: messages, Hash: sighash}
 	case apitypes.DataTyped.Mime:
 		// EIP-712 conformant typed data
 		var err error
@@ -236,6 +260,28 @@ func cliqueHeaderHashAndRlp(header *types.Header) (hash, rlp []byte, err error)
 	return hash, rlp, err
 }
 
+// borSealHeader is the header form signed by bor block producers, which leaves
+// the signature out of the extra-data and only includes the base fee once the
+// Jaipur fork is active.
+type borSealHeader struct {
+	ParentHash  common.Hash
+	UncleHash   common.Hash
+	Coinbase    common.Address
+	Root        common.Hash
+	TxHash      common.Hash
+	ReceiptHash common.Hash
+	Bloom       types.Bloom
+	Difficulty  *big.Int
+	Number      *big.Int
+	GasLimit    uint64
+	GasUsed     uint64
+	Time        uint64
+	Extra       []byte
+	MixDigest   common.Hash
+	Nonce       types.BlockNonce
+	BaseFee     *big.Int `rlp:"optional"`
+}
+
 // SignTypedData signs EIP-712 conformant typed data
 // hash = keccak256("\x19${byteVersion}${domainSeparator}${hashStruct(message)}")
 // It returns
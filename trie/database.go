@@ -88,6 +88,9 @@ type Database struct {
 	preimages    *preimageStore     // The store for caching preimages
 
 	lock sync.RWMutex
+
+	journalLock sync.Mutex // Lock serializing the clean cache journal writes
+	journalTime time.Time  // Time the clean cache was last journaled to disk
 }
 
 // rawNode is a simple binary blob used to differentiate between collapsed trie
@@ -868,6 +871,32 @@ func (db *Database) Update(nodes *MergedNodeSet) error {
 	return nil
 }
 
+// CleanSize returns the current storage size of the clean node cache, or zero
+// if the cache is disabled.
+func (db *Database) CleanSize() common.StorageSize {
+	if db.cleans == nil {
+		return 0
+	}
+
+	var stats fastcache.Stats
+	db.cleans.UpdateStats(&stats)
+
+	return common.StorageSize(stats.BytesSize)
+}
+
+// CleanEntries returns the current number of nodes in the clean node cache, or
+// zero if the cache is disabled.
+func (db *Database) CleanEntries() uint64 {
+	if db.cleans == nil {
+		return 0
+	}
+
+	var stats fastcache.Stats
+	db.cleans.UpdateStats(&stats)
+
+	return stats.EntriesCount
+}
+
 // Size returns the current storage size of the memory cache in front of the
 // persistent database layer.
 func (db *Database) Size() (common.StorageSize, common.StorageSize) {
@@ -924,6 +953,9 @@ func (db *Database) saveCache(dir string, threads int) error {
 		return nil
 	}
 
+	db.journalLock.Lock()
+	defer db.journalLock.Unlock()
+
 	log.Info("Writing clean trie cache to disk", "path", dir, "threads", threads)
 
 	start := time.Now()
@@ -934,6 +966,8 @@ func (db *Database) saveCache(dir string, threads int) error {
 		return err
 	}
 
+	db.journalTime = time.Now()
+
 	log.Info("Persisted the clean trie cache", "path", dir, "elapsed", common.PrettyDuration(time.Since(start)))
 
 	return nil
@@ -945,6 +979,23 @@ func (db *Database) SaveCache(dir string) error {
 	return db.saveCache(dir, runtime.GOMAXPROCS(0))
 }
 
+// CleanCacheJournal returns the number of entries in the clean cache along with
+// the time it was last journaled to disk, zero if it never was.
+func (db *Database) CleanCacheJournal() (uint64, time.Time) {
+	db.journalLock.Lock()
+	last := db.journalTime
+	db.journalLock.Unlock()
+
+	if db.cleans == nil {
+		return 0, last
+	}
+
+	var stats fastcache.Stats
+	db.cleans.UpdateStats(&stats)
+
+	return stats.EntriesCount, last
+}
+
 // SaveCachePeriodically atomically saves fast cache data to the given dir with
 // the specified interval. All dump operation will only use a single CPU core.
 func (db *Database) SaveCachePeriodically(dir string, interval time.Duration, stopCh <-chan struct{}) {